@@ -3,6 +3,7 @@ package ability
 
 import "image"
 import "tomo"
+import "tomo/menu"
 import "tomo/input"
 import "art"
 
@@ -31,6 +32,20 @@ type Container interface {
 	HandleChildMinimumSizeChange (child tomo.Element)
 }
 
+// LayeredContainer represents a container that places its children on top of
+// each other, so that they may overlap. Whenever one of its children is
+// redrawn, the backend redraws the children layered above it as well, and when
+// one is moved or removed, whatever was underneath it is redrawn. Other
+// containers are assumed not to overlap their children, which saves the
+// backend from having to check.
+type LayeredContainer interface {
+	Container
+
+	// ChildrenOverlap returns whether the container's children might
+	// currently overlap each other.
+	ChildrenOverlap () bool
+}
+
 // Enableable represents an element that can be enabled and disabled. Disabled
 // elements typically appear greyed out.
 type Enableable interface {
@@ -84,6 +99,37 @@ type KeyboardTarget interface {
 	HandleKeyUp (key input.Key, modifiers input.Modifiers)
 }
 
+// Mnemonic represents an element that can be activated by pressing a key while
+// holding down Alt, regardless of which element has keyboard focus. Typically,
+// the character is marked in the element's label text with an underscore.
+// Elements that are not Focusable, such as labels, may implement this in order
+// to pass focus on to another element. Mnemonics of elements that are
+// Enableable only work while they are enabled.
+type Mnemonic interface {
+	tomo.Element
+
+	// Mnemonic returns the character that activates the element, or zero
+	// if it has none.
+	Mnemonic () rune
+
+	// HandleMnemonic is called when the element's mnemonic is pressed. If
+	// more than one element in the window shares the same mnemonic, the
+	// Focusable ones are focused in turn instead, and this method is only
+	// called on the ones that are not Focusable.
+	HandleMnemonic ()
+}
+
+// Describer represents an element that describes another element, such as a
+// label next to an input field. A Describer that is also a Mnemonic only
+// responds to its mnemonic while its target is enabled.
+type Describer interface {
+	tomo.Element
+
+	// Target returns the element being described, or nil if there is
+	// none.
+	Target () tomo.Element
+}
+
 // MouseTarget represents an element that can receive mouse events.
 type MouseTarget interface {
 	tomo.Element
@@ -127,6 +173,32 @@ type MouseTargetContainer interface {
 		child tomo.Element)
 }
 
+// ContextMenuTarget represents an element that has a context menu. The backend
+// opens it when the element is right-clicked, or when the Menu key or Shift+F10
+// is pressed while the element has keyboard focus. If an element does not
+// satisfy this interface, its ancestors are checked instead.
+type ContextMenuTarget interface {
+	tomo.Element
+
+	// ContextMenu returns the items to display in the element's context
+	// menu, which will be opened at the specified position. If the menu
+	// was requested with the keyboard, the position is the bottom left
+	// corner of the focused element, unless the element satisfies
+	// ContextMenuAnchor. If nil is returned, no menu is opened.
+	ContextMenu (position image.Point) menu.Menu
+}
+
+// ContextMenuAnchor represents an element that chooses where its context menu
+// opens when it is requested with the keyboard, such as a text box that opens
+// it at the text cursor.
+type ContextMenuAnchor interface {
+	tomo.Element
+
+	// ContextMenuAnchor returns the position that the element's context
+	// menu should open at.
+	ContextMenuAnchor () image.Point
+}
+
 // MotionTarget represents an element that can receive mouse motion events.
 type MotionTarget interface {
 	tomo.Element
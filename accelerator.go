@@ -0,0 +1,118 @@
+package tomo
+
+import "errors"
+import "unicode"
+import "tomo/input"
+
+// ErrAcceleratorConflict is returned when an accelerator is added to an
+// AcceleratorTable which already has an action bound to it.
+var ErrAcceleratorConflict = errors.New("accelerator already in use")
+
+// Accelerator is a keyboard shortcut consisting of a key and a set of modifier
+// keys that must be held down while it is pressed.
+type Accelerator struct {
+	Key       input.Key
+	Modifiers input.Modifiers
+}
+
+// A creates an accelerator from a key and a set of modifiers. It is shorthand
+// for constructing an Accelerator struct.
+func A (key input.Key, modifiers input.Modifiers) Accelerator {
+	return Accelerator { Key: key, Modifiers: modifiers }
+}
+
+// ParseAccelerator parses a string such as "Ctrl+Shift+S" into an accelerator.
+// It accepts the same syntax as input.ParseChord.
+func ParseAccelerator (text string) (accelerator Accelerator, err error) {
+	key, modifiers, err := input.ParseChord(text)
+	if err != nil { return accelerator, err }
+	return A(key, modifiers).canon(), nil
+}
+
+// String returns a human-readable representation of the accelerator, such as
+// "Ctrl+Shift+S". Letters are always shown in upper case. It can be parsed by
+// ParseAccelerator.
+func (accelerator Accelerator) String () string {
+	accelerator = accelerator.canon()
+	if accelerator.Key.Printable() {
+		accelerator.Key = input.Key(unicode.ToUpper(rune(accelerator.Key)))
+	}
+	return input.FormatChord(accelerator.Key, accelerator.Modifiers)
+}
+
+// Match returns whether a key press matches the accelerator.
+func (accelerator Accelerator) Match (key input.Key, modifiers input.Modifiers) bool {
+	return accelerator.canon() == A(key, modifiers).canon()
+}
+
+// canon returns a normalized version of the accelerator that can be compared
+// against others. Letters are lowercased because the shift state is already
+// recorded in the modifiers. Symbols such as '!' already reflect the shift
+// state, so it is ignored for them. The number pad flag is always ignored.
+func (accelerator Accelerator) canon () Accelerator {
+	char := rune(accelerator.Key)
+	if accelerator.Key.Printable() && !unicode.IsSpace(char) {
+		lower := unicode.ToLower(char)
+		if lower == unicode.ToUpper(char) {
+			accelerator.Modifiers.Shift = false
+		}
+		accelerator.Key = input.Key(lower)
+	}
+	accelerator.Modifiers.NumberPad = false
+	return accelerator
+}
+
+// AcceleratorTable maps accelerators to actions. Each window has one, and it is
+// consulted whenever a key is pressed before the key press is sent to the
+// focused element. Its zero value can be used safely.
+type AcceleratorTable struct {
+	actions map[Accelerator] func ()
+}
+
+// Add binds an action to an accelerator. If the accelerator is already bound
+// to an action, ErrAcceleratorConflict is returned and the table is left
+// unchanged.
+func (table *AcceleratorTable) Add (accelerator Accelerator, action func ()) error {
+	accelerator = accelerator.canon()
+	if table.actions == nil {
+		table.actions = make(map[Accelerator] func ())
+	}
+	if _, exists := table.actions[accelerator]; exists {
+		return ErrAcceleratorConflict
+	}
+	table.actions[accelerator] = action
+	return nil
+}
+
+// AddString is like Add, but it parses the accelerator from a string using
+// ParseAccelerator.
+func (table *AcceleratorTable) AddString (text string, action func ()) error {
+	accelerator, err := ParseAccelerator(text)
+	if err != nil { return err }
+	return table.Add(accelerator, action)
+}
+
+// Remove unbinds whatever action is bound to an accelerator.
+func (table *AcceleratorTable) Remove (accelerator Accelerator) {
+	delete(table.actions, accelerator.canon())
+}
+
+// Has returns whether an action is bound to an accelerator.
+func (table *AcceleratorTable) Has (accelerator Accelerator) bool {
+	_, exists := table.actions[accelerator.canon()]
+	return exists
+}
+
+// Activate runs the action bound to the accelerator matching the given key
+// press, if there is one. It returns whether an action was run.
+func (table *AcceleratorTable) Activate (
+	key input.Key,
+	modifiers input.Modifiers,
+) (
+	handled bool,
+) {
+	action, exists := table.actions[A(key, modifiers).canon()]
+	if !exists || action == nil { return false }
+	action()
+	return true
+}
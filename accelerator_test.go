@@ -0,0 +1,105 @@
+package tomo
+
+import "errors"
+import "testing"
+import "tomo/input"
+
+func TestAcceleratorMatch (test *testing.T) {
+	control := input.Modifiers { Control: true }
+	shift   := input.Modifiers { Shift: true }
+	both    := input.Modifiers { Control: true, Shift: true }
+	cases := []struct {
+		accelerator Accelerator
+		key         input.Key
+		modifiers   input.Modifiers
+		match       bool
+	} {
+		{ A('s', control),       'S', control,   true  },
+		{ A('S', control),       's', control,   true  },
+		{ A('s', both),          'S', both,      true  },
+		{ A('s', control),       's', both,      false },
+		{ A('!', input.Modifiers { }), '!', shift, true },
+		{ A('+', control),       '+', both,      true  },
+		{ A(input.KeyEnter, input.Modifiers { }),
+			input.KeyEnter, input.Modifiers { NumberPad: true }, true },
+		{ A('5', control),       '5', input.Modifiers {
+			Control: true, NumberPad: true }, true },
+		{ A(' ', control),       ' ', both,      false },
+		{ A(input.KeyTab, control), input.KeyTab, both, false },
+	}
+
+	for _, current := range cases {
+		match := current.accelerator.Match(current.key, current.modifiers)
+		if match != current.match {
+			test.Errorf (
+				"%v matching %v: got %v, expected %v",
+				current.accelerator,
+				input.FormatChord(current.key, current.modifiers),
+				match, current.match)
+		}
+	}
+}
+
+func TestAcceleratorString (test *testing.T) {
+	cases := []struct {
+		text      string
+		canonical string
+	} {
+		{ "Ctrl+s",           "Ctrl+S"       },
+		{ "Ctrl+Shift+S",     "Ctrl+Shift+S" },
+		{ "Shift+!",          "!"            },
+		{ "NumberPad+Enter",  "Enter"        },
+	}
+
+	for _, current := range cases {
+		accelerator, err := ParseAccelerator(current.text)
+		if err != nil {
+			test.Errorf("ParseAccelerator(%q): %v", current.text, err)
+			continue
+		}
+		if accelerator.String() != current.canonical {
+			test.Errorf (
+				"ParseAccelerator(%q): got %q, expected %q",
+				current.text, accelerator.String(), current.canonical)
+		}
+	}
+}
+
+func TestAcceleratorTable (test *testing.T) {
+	control := input.Modifiers { Control: true }
+	table := AcceleratorTable { }
+	if table.Activate('s', control) {
+		test.Error("empty table handled a key press")
+	}
+
+	saved := 0
+	err := table.Add(A('s', control), func () { saved ++ })
+	if err != nil { test.Fatal(err) }
+
+	err = table.Add(A('S', control), func () { })
+	if !errors.Is(err, ErrAcceleratorConflict) {
+		test.Errorf("got %v, expected a conflict", err)
+	}
+	err = table.AddString("NumberPad+Ctrl+s", func () { })
+	if !errors.Is(err, ErrAcceleratorConflict) {
+		test.Errorf("got %v, expected a conflict", err)
+	}
+
+	if !table.Activate('S', input.Modifiers { Control: true, NumberPad: true }) {
+		test.Error("accelerator was not activated")
+	}
+	if saved != 1 {
+		test.Errorf("action ran %d times, expected once", saved)
+	}
+	if table.Activate('s', input.Modifiers { Control: true, Shift: true }) {
+		test.Error("accelerator activated with Shift held")
+	}
+
+	table.Remove(A('S', control))
+	if table.Has(A('s', control)) {
+		test.Error("accelerator was not removed")
+	}
+	if err := table.Add(A('s', control), nil); err != nil {
+		test.Errorf("re-adding a removed accelerator: %v", err)
+	}
+}
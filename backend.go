@@ -1,5 +1,6 @@
 package tomo
 
+import "time"
 import "image"
 
 // Backend represents a connection to a display server, or something similar.
@@ -31,6 +32,19 @@ type Backend interface {
 	SetConfig (Config)
 }
 
+// TickerBackend is an optional interface that a backend may implement if it is
+// able to call a function at a regular interval. Elements use it to animate
+// themselves, and skip straight to the end of any animation if the backend
+// does not implement it.
+type TickerBackend interface {
+	Backend
+
+	// NewTicker calls the specified callback within the main thread every
+	// time the interval elapses, until the returned stop function is called.
+	// Once stop has returned, the callback will not be called again.
+	NewTicker (interval time.Duration, callback func ()) (stop func ())
+}
+
 var backend Backend
 
 // GetBackend returns the currently running backend.
@@ -9,6 +9,7 @@ import "golang.org/x/image/font"
 import "golang.org/x/image/font/basicfont"
 import "tomo"
 import "tomo/data"
+import "tomo/overlays"
 import "art"
 import "art/artutil"
 import "art/patterns"
@@ -164,7 +165,22 @@ func (Default) MimeIcon (data.Mime, tomo.IconSize, tomo.Case) art.Icon {
 
 // Pattern returns a pattern from the default theme corresponding to the given
 // pattern ID.
-func (Default) Pattern (id tomo.Pattern, state tomo.State, c tomo.Case) art.Pattern {
+func (theme Default) Pattern (id tomo.Pattern, state tomo.State, c tomo.Case) art.Pattern {
+	if state.Invalid {
+		state.Invalid = false
+		return overlays.Outline {
+			Pattern: theme.Pattern(id, state, c),
+			Color:   theme.Color(tomo.ColorRed, state, c),
+		}
+	}
+	if id == tomo.PatternButton && state.Indeterminate {
+		state.Indeterminate = false
+		return overlays.Dash {
+			Pattern: theme.Pattern(id, state, c),
+			Color:   theme.Color(tomo.ColorForeground, state, c),
+		}
+	}
+
 	offset := 0; switch {
 	case state.Disabled:            offset = 1
 	case state.Pressed && state.On: offset = 4
@@ -228,6 +244,12 @@ func (Default) Padding (id tomo.Pattern, c tomo.Case) art.Inset {
 	switch id {
 	case tomo.PatternGutter: return art.I(0)
 	case tomo.PatternLine:   return art.I(1)
+	case tomo.PatternHandle:
+		if c.Match("tomo", "paned", "") {
+			return art.I(2)
+		} else {
+			return art.I(6)
+		}
 	default:                 return art.I(6)
 	}
 }
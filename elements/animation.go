@@ -0,0 +1,47 @@
+package elements
+
+import "time"
+import "tomo"
+
+// animation calls a function at a regular interval for as long as an element
+// is in a window. When the element is taken out of its window, or the window is
+// closed, the animation stops by itself. Elements that animate continuously
+// should call start again when they are drawn, so that the animation resumes
+// if the element is put back into a window.
+type animation struct {
+	stopTicker func ()
+}
+
+// start begins calling step every interval, if the animation isn't already
+// running. It returns false if the animation cannot run, either because the
+// backend does not support it or because the element isn't in a window.
+func (animation *animation) start (
+	entity tomo.Entity,
+	interval time.Duration,
+	step func (),
+) bool {
+	if animation.stopTicker != nil { return true }
+	backend, ok := tomo.GetBackend().(tomo.TickerBackend)
+	if !ok || entity.Window() == nil { return false }
+
+	animation.stopTicker = backend.NewTicker (interval, func () {
+		if entity.Window() == nil {
+			animation.stop()
+			return
+		}
+		step()
+	})
+	return true
+}
+
+// stop stops the animation if it is running.
+func (animation *animation) stop () {
+	if animation.stopTicker == nil { return }
+	animation.stopTicker()
+	animation.stopTicker = nil
+}
+
+// running returns whether the animation is running.
+func (animation *animation) running () bool {
+	return animation.stopTicker != nil
+}
@@ -13,9 +13,13 @@ type Button struct {
 	entity tomo.Entity
 	drawer textdraw.Drawer
 
+	accelerator       tomo.Accelerator
+	acceleratorDrawer textdraw.Drawer
+
 	enabled bool
 	pressed bool
-	text    string
+
+	label labelText
 
 	showText bool
 	hasIcon  bool
@@ -28,10 +32,7 @@ type Button struct {
 func NewButton (text string) (element *Button) {
 	element = &Button { showText: true, enabled: true }
 	element.entity = tomo.GetBackend().NewEntity(element)
-	element.drawer.SetFace (element.entity.Theme().FontFace (
-		tomo.FontStyleRegular,
-		tomo.FontSizeNormal,
-		buttonCase))
+	element.updateFaces()
 	element.SetText(text)
 	return
 }
@@ -52,6 +53,25 @@ func (element *Button) Draw (destination art.Canvas) {
 	foreground := element.entity.Theme().Color(tomo.ColorForeground, state, buttonCase)
 	sink       := element.entity.Theme().Sink(tomo.PatternButton, buttonCase)
 	margin     := element.entity.Theme().Margin(tomo.PatternButton, buttonCase)
+
+	if element.showAccelerator() {
+		// draw the accelerator on the right, and center everything else
+		// in the remaining space
+		padding     := element.entity.Theme().Padding(tomo.PatternButton, buttonCase)
+		textBounds  := element.acceleratorDrawer.LayoutBounds()
+		accelOffset := image.Pt (
+			padding.Apply(bounds).Max.X - textBounds.Dx(),
+			bounds.Min.Y + bounds.Dy() / 2 - textBounds.Dy() / 2)
+		accelOffset = accelOffset.Sub(textBounds.Min)
+		if element.pressed {
+			accelOffset = accelOffset.Add(sink)
+		}
+		accelColor := element.entity.Theme().Color (
+			tomo.ColorForeground,
+			tomo.State { Disabled: true }, buttonCase)
+		element.acceleratorDrawer.Draw(destination, accelColor, accelOffset)
+		bounds.Max.X -= textBounds.Dx() + margin.X
+	}
 	
 	offset := image.Pt (
 		bounds.Dx() / 2,
@@ -95,6 +115,7 @@ func (element *Button) Draw (destination art.Canvas) {
 			offset = offset.Add(sink)
 		}
 		element.drawer.Draw(destination, foreground, offset)
+		element.label.underline(element.drawer, destination, foreground, offset)
 	}
 }
 
@@ -122,9 +143,20 @@ func (element *Button) SetEnabled (enabled bool) {
 
 // SetText sets the button's label text.
 func (element *Button) SetText (text string) {
-	if element.text == text { return }
-	element.text = text
-	element.drawer.SetText([]rune(text))
+	element.setText(text, false)
+}
+
+// SetTextWithMnemonic is like SetText, but an underscore marks the character
+// after it as the mnemonic, which can be pressed along with Alt to activate the
+// button. Use two underscores to display a literal underscore.
+func (element *Button) SetTextWithMnemonic (text string) {
+	element.setText(text, true)
+}
+
+func (element *Button) setText (text string, parse bool) {
+	display, changed := element.label.set(text, parse)
+	if !changed { return }
+	element.drawer.SetText(display)
 	element.updateMinimumSize()
 	element.entity.Invalidate()
 }
@@ -143,6 +175,27 @@ func (element *Button) SetIcon (id tomo.Icon) {
 	element.entity.Invalidate()
 }
 
+// SetAccelerator sets the accelerator that is displayed alongside the button's
+// text. This is purely visual—the accelerator must still be registered with
+// the window's accelerator table in order for it to do anything. Passing an
+// accelerator with a key of input.KeyNone removes it.
+func (element *Button) SetAccelerator (accelerator tomo.Accelerator) {
+	if element.accelerator == accelerator { return }
+	element.accelerator = accelerator
+	if accelerator.Key == input.KeyNone {
+		element.acceleratorDrawer.SetText(nil)
+	} else {
+		element.acceleratorDrawer.SetText([]rune(accelerator.String()))
+	}
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+}
+
+// Accelerator returns the accelerator displayed by the button.
+func (element *Button) Accelerator () tomo.Accelerator {
+	return element.accelerator
+}
+
 // ShowText sets whether or not the button's text will be displayed.
 func (element *Button) ShowText (showText bool) {
 	if element.showText == showText { return }
@@ -152,14 +205,22 @@ func (element *Button) ShowText (showText bool) {
 }
 
 func (element *Button) HandleThemeChange () {
-	element.drawer.SetFace (element.entity.Theme().FontFace (
-		tomo.FontStyleRegular,
-		tomo.FontSizeNormal,
-		buttonCase))
+	element.updateFaces()
 	element.updateMinimumSize()
 	element.entity.Invalidate()
 }
 
+func (element *Button) Mnemonic () rune {
+	return element.label.mnemonic
+}
+
+func (element *Button) HandleMnemonic () {
+	element.Focus()
+	if element.onClick != nil {
+		element.onClick()
+	}
+}
+
 func (element *Button) HandleFocusChange () {
 	element.entity.Invalidate()
 }
@@ -229,10 +290,28 @@ func (element *Button) updateMinimumSize () {
 		}
 	}
 	
+	if element.showAccelerator() {
+		textBounds := element.acceleratorDrawer.LayoutBounds()
+		minimumSize.Max.X += textBounds.Dx() + margin.X
+	}
+	
 	minimumSize = padding.Inverse().Apply(minimumSize)
 	element.entity.SetMinimumSize(minimumSize.Dx(), minimumSize.Dy())
 }
 
+func (element *Button) updateFaces () {
+	face := element.entity.Theme().FontFace (
+		tomo.FontStyleRegular,
+		tomo.FontSizeNormal,
+		buttonCase)
+	element.drawer.SetFace(face)
+	element.acceleratorDrawer.SetFace(face)
+}
+
+func (element *Button) showAccelerator () bool {
+	return element.showText && element.accelerator.Key != input.KeyNone
+}
+
 func (element *Button) state () tomo.State {
 	return tomo.State {
 		Disabled: !element.Enabled(),
@@ -0,0 +1,156 @@
+package elements
+
+import "image"
+import "tomo"
+import "tomo/input"
+import "art"
+import "tomo/textdraw"
+
+// checkable holds what Checkbox and RadioButton have in common: a box drawn
+// with the button pattern, followed by a label.
+type checkable struct {
+	entity tomo.Entity
+	drawer textdraw.Drawer
+	c      tomo.Case
+
+	enabled bool
+	pressed bool
+
+	label labelText
+}
+
+func (element *checkable) construct (outer tomo.Element, c tomo.Case, text string) {
+	element.c       = c
+	element.enabled = true
+	element.entity  = tomo.GetBackend().NewEntity(outer)
+	element.updateFace()
+	element.setText(text, false)
+}
+
+// Entity returns this element's entity.
+func (element *checkable) Entity () tomo.Entity {
+	return element.entity
+}
+
+// Focus gives this element input focus.
+func (element *checkable) Focus () {
+	if !element.entity.Focused() { element.entity.Focus() }
+}
+
+// Enabled returns whether this element is enabled or not.
+func (element *checkable) Enabled () bool {
+	return element.enabled
+}
+
+// SetEnabled sets whether this element can be toggled or not.
+func (element *checkable) SetEnabled (enabled bool) {
+	if element.enabled == enabled { return }
+	element.enabled = enabled
+	element.entity.Invalidate()
+}
+
+// SetText sets the element's label text.
+func (element *checkable) SetText (text string) {
+	element.setText(text, false)
+}
+
+// SetTextWithMnemonic is like SetText, but an underscore marks the character
+// after it as the mnemonic, which can be pressed along with Alt to activate the
+// element. Use two underscores to display a literal underscore.
+func (element *checkable) SetTextWithMnemonic (text string) {
+	element.setText(text, true)
+}
+
+func (element *checkable) Mnemonic () rune {
+	return element.label.mnemonic
+}
+
+func (element *checkable) HandleThemeChange () {
+	element.updateFace()
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+}
+
+func (element *checkable) HandleFocusChange () {
+	element.entity.Invalidate()
+}
+
+func (element *checkable) HandleMouseDown (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) {
+	if !element.Enabled() { return }
+	element.Focus()
+	element.pressed = true
+	element.entity.Invalidate()
+}
+
+// release ends a mouse press, and returns whether the button was released over
+// the element.
+func (element *checkable) release (position image.Point, button input.Button) bool {
+	if button != input.ButtonLeft || !element.pressed { return false }
+	element.pressed = false
+	element.entity.Invalidate()
+	return position.In(element.entity.Bounds())
+}
+
+func (element *checkable) setText (text string, parse bool) {
+	display, changed := element.label.set(text, parse)
+	if !changed { return }
+	element.drawer.SetText(display)
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+}
+
+// state returns the parts of the element's state that do not depend on whether
+// it is checked.
+func (element *checkable) state () tomo.State {
+	return tomo.State {
+		Disabled: !element.Enabled(),
+		Focused:  element.entity.Focused(),
+		Pressed:  element.pressed,
+	}
+}
+
+func (element *checkable) draw (destination art.Canvas, state tomo.State) {
+	bounds := element.entity.Bounds()
+	boxBounds := image.Rect(0, 0, bounds.Dy(), bounds.Dy()).Add(bounds.Min)
+
+	element.entity.DrawBackground(destination)
+
+	pattern := element.entity.Theme().Pattern(tomo.PatternButton, state, element.c)
+	pattern.Draw(destination, boxBounds)
+
+	textBounds := element.drawer.LayoutBounds()
+	margin := element.entity.Theme().Margin(tomo.PatternBackground, element.c)
+	offset := bounds.Min.Add(image.Point {
+		X: bounds.Dy() + margin.X,
+	})
+
+	offset.Y -= textBounds.Min.Y
+	offset.X -= textBounds.Min.X
+
+	foreground := element.entity.Theme().Color(tomo.ColorForeground, state, element.c)
+	element.drawer.Draw(destination, foreground, offset)
+	element.label.underline(element.drawer, destination, foreground, offset)
+}
+
+func (element *checkable) updateFace () {
+	element.drawer.SetFace (element.entity.Theme().FontFace (
+		tomo.FontStyleRegular,
+		tomo.FontSizeNormal,
+		element.c))
+}
+
+func (element *checkable) updateMinimumSize () {
+	textBounds := element.drawer.LayoutBounds()
+	if element.label.text == "" {
+		element.entity.SetMinimumSize(textBounds.Dy(), textBounds.Dy())
+	} else {
+		margin := element.entity.Theme().Margin(tomo.PatternBackground, element.c)
+		element.entity.SetMinimumSize (
+			textBounds.Dy() + margin.X + textBounds.Dx(),
+			textBounds.Dy())
+	}
+}
@@ -4,68 +4,70 @@ import "image"
 import "tomo"
 import "tomo/input"
 import "art"
-import "tomo/textdraw"
 
 var checkboxCase = tomo.C("tomo", "checkbox")
 
-// Checkbox is a toggle-able checkbox with a label.
+// CheckState is the state of a checkbox.
+type CheckState int; const (
+	CheckStateUnchecked CheckState = iota
+	CheckStateChecked
+
+	// CheckStateIndeterminate means that the checkbox is neither checked
+	// nor unchecked. This is typically used for a checkbox that controls a
+	// list of items where only some of them are selected.
+	CheckStateIndeterminate
+)
+
+// CheckCycle determines which state a checkbox moves to when it is toggled by
+// the user, given its current state.
+type CheckCycle func (current CheckState) CheckState
+
+// CycleTwoState toggles between checked and unchecked. An indeterminate
+// checkbox becomes checked. The user can never make a checkbox indeterminate
+// with this cycle, but the application can. This is the default cycle.
+func CycleTwoState (current CheckState) CheckState {
+	if current == CheckStateChecked {
+		return CheckStateUnchecked
+	} else {
+		return CheckStateChecked
+	}
+}
+
+// CycleThreeState moves from unchecked, to checked, to indeterminate, and back
+// to unchecked.
+func CycleThreeState (current CheckState) CheckState {
+	switch current {
+	case CheckStateUnchecked: return CheckStateChecked
+	case CheckStateChecked:   return CheckStateIndeterminate
+	default:                  return CheckStateUnchecked
+	}
+}
+
+// Checkbox is a toggle-able checkbox with a label. In addition to being checked
+// or unchecked, it can be in an indeterminate state.
 type Checkbox struct {
-	entity tomo.Entity
-	drawer textdraw.Drawer
-
-	enabled bool
-	pressed bool
-	checked bool
-	text    string
-	
+	checkable
+
+	checkState CheckState
+	cycle      CheckCycle
+
 	onToggle func ()
 }
 
 // NewCheckbox creates a new cbeckbox with the specified label text.
 func NewCheckbox (text string, checked bool) (element *Checkbox) {
-	element = &Checkbox { checked: checked, enabled: true }
-	element.entity = tomo.GetBackend().NewEntity(element)
-	element.drawer.SetFace (element.entity.Theme().FontFace (
-		tomo.FontStyleRegular,
-		tomo.FontSizeNormal,
-		checkboxCase))
-	element.SetText(text)
+	element = &Checkbox { cycle: CycleTwoState }
+	if checked { element.checkState = CheckStateChecked }
+	element.construct(element, checkboxCase, text)
 	return
 }
 
-// Entity returns this element's entity.
-func (element *Checkbox) Entity () tomo.Entity {
-	return element.entity
-}
-
 // Draw causes the element to draw to the specified destination canvas.
 func (element *Checkbox) Draw (destination art.Canvas) {
-	bounds := element.entity.Bounds()
-	boxBounds := image.Rect(0, 0, bounds.Dy(), bounds.Dy()).Add(bounds.Min)
-
-	state := tomo.State {
-		Disabled: !element.Enabled(),
-		Focused:  element.entity.Focused(),
-		Pressed:  element.pressed,
-		On:       element.checked,
-	}
-
-	element.entity.DrawBackground(destination)
-		
-	pattern := element.entity.Theme().Pattern(tomo.PatternButton, state, checkboxCase)
-	pattern.Draw(destination, boxBounds)
-
-	textBounds := element.drawer.LayoutBounds()
-	margin := element.entity.Theme().Margin(tomo.PatternBackground, checkboxCase)
-	offset := bounds.Min.Add(image.Point {
-		X: bounds.Dy() + margin.X,
-	})
-
-	offset.Y -= textBounds.Min.Y
-	offset.X -= textBounds.Min.X
-
-	foreground := element.entity.Theme().Color(tomo.ColorForeground, state, checkboxCase)
-	element.drawer.Draw(destination, foreground, offset)
+	state := element.state()
+	state.On            = element.checkState == CheckStateChecked
+	state.Indeterminate = element.checkState == CheckStateIndeterminate
+	element.draw(destination, state)
 }
 
 // OnToggle sets the function to be called when the checkbox is toggled.
@@ -73,59 +75,36 @@ func (element *Checkbox) OnToggle (callback func ()) {
 	element.onToggle = callback
 }
 
-// Value reports whether or not the checkbox is currently checked.
+// Value reports whether or not the checkbox is currently checked. An
+// indeterminate checkbox is not considered checked.
 func (element *Checkbox) Value () (checked bool) {
-	return element.checked
-}
-
-// Focus gives this element input focus.
-func (element *Checkbox) Focus () {
-	if !element.entity.Focused() { element.entity.Focus() }
-}
-
-// Enabled returns whether this checkbox is enabled or not.
-func (element *Checkbox) Enabled () bool {
-	return element.enabled
+	return element.checkState == CheckStateChecked
 }
 
-// SetEnabled sets whether this checkbox can be toggled or not.
-func (element *Checkbox) SetEnabled (enabled bool) {
-	if element.enabled == enabled { return }
-	element.enabled = enabled
-	element.entity.Invalidate()
-}
-
-// SetText sets the checkbox's label text.
-func (element *Checkbox) SetText (text string) {
-	if element.text == text { return }
-	element.text = text
-	element.drawer.SetText([]rune(text))
-	element.updateMinimumSize()
-	element.entity.Invalidate()
+// State returns the current state of the checkbox.
+func (element *Checkbox) State () CheckState {
+	return element.checkState
 }
 
-func (element *Checkbox) HandleThemeChange () {
-	element.drawer.SetFace (element.entity.Theme().FontFace (
-		tomo.FontStyleRegular,
-		tomo.FontSizeNormal,
-		checkboxCase))
-	element.updateMinimumSize()
+// SetState sets the state of the checkbox. This does not call the OnToggle
+// callback.
+func (element *Checkbox) SetState (state CheckState) {
+	if element.checkState == state { return }
+	element.checkState = state
 	element.entity.Invalidate()
 }
 
-func (element *Checkbox) HandleFocusChange () {
-	element.entity.Invalidate()
+// SetCycle sets the function that determines which state the checkbox moves to
+// when it is toggled by the user. Passing nil restores the default,
+// CycleTwoState.
+func (element *Checkbox) SetCycle (cycle CheckCycle) {
+	if cycle == nil { cycle = CycleTwoState }
+	element.cycle = cycle
 }
 
-func (element *Checkbox) HandleMouseDown (
-	position image.Point,
-	button input.Button,
-	modifiers input.Modifiers,
-) {
-	if !element.Enabled() { return }
+func (element *Checkbox) HandleMnemonic () {
 	element.Focus()
-	element.pressed = true
-	element.entity.Invalidate()
+	element.toggle()
 }
 
 func (element *Checkbox) HandleMouseUp (
@@ -133,17 +112,8 @@ func (element *Checkbox) HandleMouseUp (
 	button input.Button,
 	modifiers input.Modifiers,
 ) {
-	if button != input.ButtonLeft || !element.pressed { return }
-
-	element.pressed = false
-	within := position.In(element.entity.Bounds())
-	if within {
-		element.checked = !element.checked
-	}
-	
-	element.entity.Invalidate()
-	if within && element.onToggle != nil {
-		element.onToggle()
+	if element.release(position, button) {
+		element.toggle()
 	}
 }
 
@@ -157,22 +127,15 @@ func (element *Checkbox) HandleKeyDown (key input.Key, modifiers input.Modifiers
 func (element *Checkbox) HandleKeyUp (key input.Key, modifiers input.Modifiers) {
 	if key == input.KeyEnter && element.pressed {
 		element.pressed = false
-		element.checked = !element.checked
-		element.entity.Invalidate()
-		if element.onToggle != nil {
-			element.onToggle()
-		}
+		element.toggle()
 	}
 }
 
-func (element *Checkbox) updateMinimumSize () {
-	textBounds := element.drawer.LayoutBounds()
-	if element.text == "" {
-		element.entity.SetMinimumSize(textBounds.Dy(), textBounds.Dy())
-	} else {
-		margin := element.entity.Theme().Margin(tomo.PatternBackground, checkboxCase)
-		element.entity.SetMinimumSize (
-			textBounds.Dy() + margin.X + textBounds.Dx(),
-			textBounds.Dy())
+// toggle moves the checkbox to its next state in response to user input.
+func (element *Checkbox) toggle () {
+	element.checkState = element.cycle(element.checkState)
+	element.entity.Invalidate()
+	if element.onToggle != nil {
+		element.onToggle()
 	}
 }
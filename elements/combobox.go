@@ -1,11 +1,13 @@
 package elements
 
 import "image"
+import "strings"
 import "tomo"
 import "tomo/input"
 import "art"
 import "tomo/ability"
 import "tomo/textdraw"
+import "tomo/textmanip"
 
 var comboBoxCase = tomo.C("tomo", "comboBox")
 
@@ -20,17 +22,34 @@ func (option Option) Title () string {
 	}
 }
 
-// ComboBox is an input that can be one of several predetermined values.
+// OptionProvider supplies the options of a ComboBox on demand, given the text
+// that the user has typed into its search field. If the query is empty, it
+// should return every option, or a reasonable subset of them if there are too
+// many to display.
+type OptionProvider func (query string) []Option
+
+// OptionLabeler returns the text and icon that a ComboBox displays for an
+// option. This allows the value of an option to be different from what the
+// user sees. An icon of tomo.IconNone means that the option has no icon.
+type OptionLabeler func (option Option) (title string, icon tomo.Icon)
+
+// ComboBox is an input that can be one of several predetermined values. When it
+// is opened, the user can type to filter the list of options. If the combo box
+// is editable, the user may also enter a value that is not in the list, which
+// allows it to be used as an autocomplete field.
 type ComboBox struct {
 	entity tomo.Entity
 	drawer textdraw.Drawer
 
 	options  []Option
 	selected Option
+	provider OptionProvider
+	labeler  OptionLabeler
+
+	enabled  bool
+	pressed  bool
+	editable bool
 
-	enabled bool
-	pressed bool
-	
 	onChange func ()
 }
 
@@ -56,37 +75,36 @@ func (element *ComboBox) Entity () tomo.Entity {
 func (element *ComboBox) Draw (destination art.Canvas) {
 	state   := element.state()
 	bounds  := element.entity.Bounds()
-	pattern := element.entity.Theme().Pattern(tomo.PatternButton, state, comboBoxCase)
+	pattern := element.entity.Theme().Pattern(element.pattern(), state, comboBoxCase)
 
 	pattern.Draw(destination, bounds)
-	
+
 	foreground := element.entity.Theme().Color(tomo.ColorForeground, state, comboBoxCase)
 	sink       := element.entity.Theme().Sink(tomo.PatternButton, comboBoxCase)
 	margin     := element.entity.Theme().Margin(tomo.PatternButton, comboBoxCase)
-	padding    := element.entity.Theme().Padding(tomo.PatternButton, comboBoxCase)
-	
+	padding    := element.entity.Theme().Padding(element.pattern(), comboBoxCase)
+
 	offset := image.Pt(0, bounds.Dy() / 2).Add(bounds.Min)
 
 	textBounds := element.drawer.LayoutBounds()
 	offset.Y -= textBounds.Dy() / 2
 	offset.Y -= textBounds.Min.Y
 	offset.X -= textBounds.Min.X
+	offset.X += padding[3]
 
-	icon := element.entity.Theme().Icon(tomo.IconExpand, tomo.IconSizeSmall, comboBoxCase) 
-	if icon != nil {
+	_, iconId := element.label(element.selected)
+	for _, id := range []tomo.Icon { tomo.IconExpand, iconId } {
+		if id == tomo.IconNone { continue }
+		icon := element.entity.Theme().Icon(id, tomo.IconSizeSmall, comboBoxCase)
+		if icon == nil { continue }
 		iconBounds := icon.Bounds()
-		addedWidth := iconBounds.Dx() + margin.X
-		iconOffset := bounds.Min
-
-		iconOffset.X += padding[3]
-		iconOffset.Y =
-			bounds.Min.Y +
-			(bounds.Dy() -
-			iconBounds.Dy()) / 2
+		iconOffset := image.Pt (
+			offset.X + textBounds.Min.X,
+			bounds.Min.Y + (bounds.Dy() - iconBounds.Dy()) / 2)
 		if element.pressed {
 			iconOffset = iconOffset.Add(sink)
 		}
-		offset.X += addedWidth + padding[3]
+		offset.X += iconBounds.Dx() + margin.X
 
 		icon.Draw(destination, foreground, iconOffset)
 	}
@@ -110,14 +128,48 @@ func (element *ComboBox) Value () Option {
 // Select sets this element's value.
 func (element *ComboBox) Select (option Option) {
 	element.selected = option
-	element.drawer.SetText([]rune(option.Title()))
-	element.updateMinimumSize()
-	element.entity.Invalidate()
+	element.updateLabel()
 	if element.onChange != nil {
 		element.onChange()
 	}
 }
 
+// SetOptions replaces the options that the user can choose from. This has no
+// effect if an OptionProvider has been set.
+func (element *ComboBox) SetOptions (options ...Option) {
+	element.options = options
+}
+
+// SetProvider sets a function that supplies options dynamically, in place of
+// the options given to NewComboBox or SetOptions. Passing nil removes it.
+func (element *ComboBox) SetProvider (provider OptionProvider) {
+	element.provider = provider
+}
+
+// SetLabeler sets a function that determines the text and icon displayed for
+// each option. Passing nil restores the default, which displays the option's
+// Title and no icon.
+func (element *ComboBox) SetLabeler (labeler OptionLabeler) {
+	element.labeler = labeler
+	element.updateLabel()
+}
+
+// Editable returns whether the user can enter values other than the options
+// given to the combo box.
+func (element *ComboBox) Editable () bool {
+	return element.editable
+}
+
+// SetEditable sets whether the user can enter values other than the options
+// given to the combo box. When a combo box is editable, the text typed into its
+// search field is accepted as its value if no option is chosen.
+func (element *ComboBox) SetEditable (editable bool) {
+	if element.editable == editable { return }
+	element.editable = editable
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+}
+
 // Filled returns whether this element has a value other than (None).
 func (element *ComboBox) Filled () bool {
 	return element.selected != ""
@@ -161,7 +213,7 @@ func (element *ComboBox) HandleMouseDown (
 	if !element.Enabled() { return }
 	element.Focus()
 	if button != input.ButtonLeft { return }
-	element.dropDown()
+	element.dropDown(element.initialQuery())
 }
 
 func (element *ComboBox) HandleMouseUp (
@@ -174,31 +226,36 @@ func (element *ComboBox) HandleKeyDown (key input.Key, modifiers input.Modifiers
 	if !element.Enabled() { return }
 
 	selectionDelta := 0
-	switch key {
-	case input.KeyEnter:
+	switch {
+	case key == input.KeyEnter:
 		element.pressed = true
 		element.entity.Invalidate()
-	case input.KeyUp, input.KeyLeft:
+	case key == input.KeyUp, key == input.KeyLeft:
 		selectionDelta = -1
-	case input.KeyDown, input.KeyRight:
+	case key == input.KeyDown, key == input.KeyRight:
 		selectionDelta = 1
+	case key.Printable() && key != ' ' && !modifiers.Control:
+		// start searching with the typed character
+		element.dropDown(element.initialQuery() + string(rune(key)))
 	}
 
 	if selectionDelta != 0 {
+		options  := element.provide("")
+		if len(options) == 0 { return }
 		selected := 0
-		for index, option := range element.options {
+		for index, option := range options {
 			if option == element.selected {
 				selected = index
 			}
 		}
 		selected += selectionDelta
 		if selected < 0 {
-			selected = len(element.options) - 1
-		} else if selected >= len(element.options) {
+			selected = len(options) - 1
+		} else if selected >= len(options) {
 			selected = 0
 		}
 
-		element.Select(element.options[selected])
+		element.Select(options[selected])
 	}
 }
 
@@ -207,54 +264,161 @@ func (element *ComboBox) HandleKeyUp(key input.Key, modifiers input.Modifiers) {
 		element.pressed = false
 		element.entity.Invalidate()
 		if !element.Enabled() { return }
-		element.dropDown()
+		element.dropDown(element.initialQuery())
 	}
 }
 
-func (element *ComboBox) dropDown () {
+func (element *ComboBox) dropDown (query string) {
 	window := element.entity.Window()
+	if window == nil { return }
 	menu, err := window.NewMenu(element.entity.Bounds())
 	if err != nil { return }
 
 	cellToOption := make(map[ability.Selectable] Option)
+	search := NewTextBox("Search", "")
+	list   := NewList()
+	list.Collapse(0, element.entity.Bounds().Dy() * 8)
 
-	list := NewList()
-	for _, option := range element.options {
-		option := option
-		cell := NewCell(NewLabel(option.Title()))
-		cellToOption[cell] = option
-		list.Adopt(cell)
-
-		if option == element.selected {
-			list.Select(cell)
+	accept := func (option Option) {
+		menu.Close()
+		element.Select(option)
+	}
+	populate := func () {
+		list.DisownAll()
+		cellToOption = make(map[ability.Selectable] Option)
+		for _, option := range element.provide(search.Value()) {
+			cell := element.newOptionCell(option)
+			cellToOption[cell] = option
+			list.Adopt(cell)
+
+			// in editable mode the query is free text, so an option is
+			// only highlighted if it is exactly what has been typed.
+			// otherwise pressing Enter would throw the text away.
+			highlight := option == element.selected
+			if element.editable {
+				title, _ := element.label(option)
+				highlight = title == search.Value()
+			}
+			if highlight {
+				list.Select(cell)
+			}
+		}
+		if list.Selected() == nil && !element.editable {
+			if first, ok := list.Child(0).(ability.Selectable); ok {
+				list.Select(first)
+			}
 		}
 	}
+
 	list.OnClick(func () {
 		selected := list.Selected()
 		if selected == nil { return }
-		element.Select(cellToOption[selected])
-		menu.Close()
+		accept(cellToOption[selected])
 	})
-
-	menu.Adopt(list)
-	list.Focus()
+	search.OnChange(populate)
+	search.OnKeyDown(func (key input.Key, modifiers input.Modifiers) bool {
+		switch key {
+		case input.KeyUp, input.KeyDown:
+			list.HandleKeyDown(key, modifiers)
+			return true
+		case input.KeyEnter:
+			if selected := list.Selected(); selected != nil {
+				accept(cellToOption[selected])
+			} else if element.editable {
+				accept(Option(search.Value()))
+			}
+			return true
+		}
+		return false
+	})
+	search.SetValue(query)
+	search.SetDot(textmanip.EmptyDot(len([]rune(query))))
+
+	menu.Adopt (NewVBox (
+		SpaceNone,
+		search,
+		NewScroll(ScrollVertical, list)))
+	search.Focus()
 	menu.Show()
 }
 
+func (element *ComboBox) newOptionCell (option Option) *Cell {
+	title, icon := element.label(option)
+	if icon == tomo.IconNone {
+		return NewCell(NewLabel(title))
+	}
+	return NewCell (NewHBox (
+		SpaceMargin,
+		NewIcon(icon, tomo.IconSizeSmall),
+		NewLabel(title)))
+}
+
+// updateLabel updates the text and icon displayed for the current value.
+func (element *ComboBox) updateLabel () {
+	title, _ := element.label(element.selected)
+	element.drawer.SetText([]rune(title))
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+}
+
+// initialQuery returns the text that the search field starts out with when the
+// combo box is opened.
+func (element *ComboBox) initialQuery () string {
+	if element.editable {
+		return string(element.selected)
+	} else {
+		return ""
+	}
+}
+
+// provide returns the options matching a query, using the provider if there is
+// one. Otherwise, it returns the options who's titles contain the query,
+// ignoring case.
+func (element *ComboBox) provide (query string) []Option {
+	if element.provider != nil { return element.provider(query) }
+	if query == "" { return element.options }
+
+	query = strings.ToLower(query)
+	options := []Option { }
+	for _, option := range element.options {
+		title, _ := element.label(option)
+		if strings.Contains(strings.ToLower(title), query) {
+			options = append(options, option)
+		}
+	}
+	return options
+}
+
+func (element *ComboBox) label (option Option) (title string, icon tomo.Icon) {
+	if element.labeler != nil { return element.labeler(option) }
+	if element.editable { return string(option), tomo.IconNone }
+	return option.Title(), tomo.IconNone
+}
+
+func (element *ComboBox) pattern () tomo.Pattern {
+	if element.editable {
+		return tomo.PatternInput
+	} else {
+		return tomo.PatternButton
+	}
+}
+
 func (element *ComboBox) updateMinimumSize () {
-	padding := element.entity.Theme().Padding(tomo.PatternButton, comboBoxCase)
+	padding := element.entity.Theme().Padding(element.pattern(), comboBoxCase)
 	margin  := element.entity.Theme().Margin(tomo.PatternButton, comboBoxCase)
 
 	textBounds  := element.drawer.LayoutBounds()
 	minimumSize := textBounds.Sub(textBounds.Min)
-	
-	icon := element.entity.Theme().Icon(tomo.IconExpand, tomo.IconSizeSmall, comboBoxCase) 
-	if icon != nil {
-		bounds := icon.Bounds()
-		minimumSize.Max.X += bounds.Dx()
+
+	_, iconId := element.label(element.selected)
+	for _, id := range []tomo.Icon { tomo.IconExpand, iconId } {
+		if id == tomo.IconNone { continue }
+		icon := element.entity.Theme().Icon(id, tomo.IconSizeSmall, comboBoxCase)
+		if icon == nil { continue }
+		minimumSize.Max.X += icon.Bounds().Dx()
 		minimumSize.Max.X += margin.X
 	}
-	
+
 	minimumSize = padding.Inverse().Apply(minimumSize)
 	element.entity.SetMinimumSize(minimumSize.Dx(), minimumSize.Dy())
 }
@@ -1,6 +1,7 @@
 package elements
 
 import "image"
+import "sort"
 import "path/filepath"
 import "tomo"
 import "tomo/input"
@@ -8,10 +9,10 @@ import "art"
 import "tomo/ability"
 import "art/shatter"
 
-// TODO: base on flow implementation of list. also be able to switch to a table
-// variant for a more information dense view.
+// TODO: base on flow implementation of list.
 
-var directoryCase = tomo.C("tomo", "list")
+var directoryCase  = tomo.C("tomo", "list")
+var rubberBandCase = tomo.C("tomo", "rubberBand")
 
 type historyEntry struct {
 	location string
@@ -19,14 +20,37 @@ type historyEntry struct {
 }
 
 // Directory displays a list of files within a particular directory and
-// file system.
+// file system. Files can either be shown as a grid of icons, or as a table
+// listing their details.
 type Directory struct {
 	container
 	entity tomo.Entity
 	
+	items    childItems
+	selector selector
+	enabled  bool
+	
+	view    DirectoryView
+	table   *Table
+	entries []directoryEntry
+	
+	sortColumn       DirectoryColumn
+	descending       bool
+	directoriesFirst bool
+	showHidden       bool
+	
 	scroll        image.Point
 	contentBounds image.Rectangle
 	
+	// while the user is dragging out a rubber band selection, bandStart
+	// and bandEnd are its corners relative to the content, and bandBase
+	// holds whatever was selected beforehand that should stay selected
+	banding   bool
+	band      *rubberBand
+	bandStart image.Point
+	bandEnd   image.Point
+	bandBase  map[tomo.Element] bool
+	
 	history      []historyEntry
 	historyIndex int
 	
@@ -43,9 +67,15 @@ func NewDirectory (
 	element *Directory,
 	err error,
 ) {
-	element = &Directory { }
+	element = &Directory {
+		enabled:          true,
+		directoriesFirst: true,
+	}
 	element.entity = tomo.GetBackend().NewEntity(element)
 	element.container.entity = element.entity
+	element.items.entity     = element.entity
+	element.selector.items   = &element.items
+	element.selector.mode    = SelectionMultiple
 	element.minimumSize = element.updateMinimumSize
 	element.init()
 	err = element.SetLocation(location, within)
@@ -53,9 +83,13 @@ func NewDirectory (
 }
 
 func (element *Directory) Draw (destination art.Canvas) {
-	rocks := make([]image.Rectangle, element.entity.CountChildren())
+	// the rubber band is only an outline, so the background must be drawn
+	// underneath it
+	rocks := []image.Rectangle { }
 	for index := 0; index < element.entity.CountChildren(); index ++ {
-		rocks[index] = element.entity.Child(index).Entity().Bounds()
+		child := element.entity.Child(index)
+		if child == element.band { continue }
+		rocks = append(rocks, child.Entity().Bounds())
 	}
 
 	tiles := shatter.Shatter(element.entity.Bounds(), rocks...)
@@ -65,6 +99,13 @@ func (element *Directory) Draw (destination art.Canvas) {
 }
 
 func (element *Directory) Layout () {
+	if element.view == DirectoryViewDetails {
+		// the table has its own padding and scrolls itself
+		element.entity.PlaceChild(0, element.entity.Bounds())
+		element.scrollBoundsChanged()
+		return
+	}
+
 	if element.scroll.Y > element.maxScrollHeight() {
 		element.scroll.Y = element.maxScrollHeight()
 	}
@@ -87,6 +128,7 @@ func (element *Directory) Layout () {
 	
 	for index := 0; index < element.entity.CountChildren(); index ++ {
 		child := element.entity.Child(index)
+		if child == element.band { continue }
 		entry := element.scratch[child]
 	
 		width  := int(entry.minBreadth)
@@ -112,10 +154,193 @@ func (element *Directory) Layout () {
 	
 	element.contentBounds =
 		element.contentBounds.Sub(element.contentBounds.Min)
-		
-	element.entity.NotifyScrollBoundsChange()
-	if element.onScrollBoundsChange != nil {
-		element.onScrollBoundsChange()
+	element.placeBand()
+	element.scrollBoundsChanged()
+}
+
+// Disown removes one or more elements from the directory view. Elements that
+// are removed are no longer part of the selection.
+func (element *Directory) Disown (children ...tomo.Element) {
+	element.container.Disown(children...)
+	element.items.forget()
+}
+
+// DisownAll removes all elements from the directory view.
+func (element *Directory) DisownAll () {
+	element.banding = false
+	element.band    = nil
+	element.container.DisownAll()
+	element.items.forget()
+}
+
+// Selection returns all selected files, in order. When files are shown in
+// the details view, they are not elements and this returns nil. Use
+// SelectedFiles instead to get the selection in either view.
+func (element *Directory) Selection () []ability.Selectable {
+	if element.view == DirectoryViewDetails { return nil }
+	return element.items.selection()
+}
+
+// SelectedFiles returns the locations of all selected files, in the order that
+// they are shown.
+func (element *Directory) SelectedFiles () (locations []string) {
+	if element.view == DirectoryViewDetails {
+		for _, row := range element.table.Selected() {
+			locations = append(locations, element.entries[row].location)
+		}
+		return
+	}
+	for _, child := range element.items.selection() {
+		if file, ok := child.(*File); ok {
+			location, _ := file.Location()
+			locations = append(locations, location)
+		}
+	}
+	return
+}
+
+// SelectAll selects every file, if more than one file can be selected.
+func (element *Directory) SelectAll () {
+	if element.view == DirectoryViewDetails {
+		if element.selector.mode != SelectionMultiple { return }
+		rows := make([]int, len(element.entries))
+		for row := range rows { rows[row] = row }
+		element.table.Select(rows...)
+		return
+	}
+	element.selector.selectAll()
+}
+
+// SelectionMode returns how many files can be selected at once.
+func (element *Directory) SelectionMode () SelectionMode {
+	return element.selector.mode
+}
+
+// SetSelectionMode sets how many files can be selected at once. By default, any
+// amount of files can be selected.
+func (element *Directory) SetSelectionMode (mode SelectionMode) {
+	element.selector.setMode(mode)
+	if element.table != nil { element.table.SetSelectionMode(mode) }
+}
+
+// OnSelectionChange sets a function to be called when files are selected or
+// deselected.
+func (element *Directory) OnSelectionChange (callback func ()) {
+	element.selector.onSelectionChange = callback
+}
+
+// Focus gives this element input focus.
+func (element *Directory) Focus () {
+	if element.view == DirectoryViewDetails {
+		element.table.Focus()
+		return
+	}
+	if !element.entity.Focused() { element.entity.Focus() }
+}
+
+// Enabled returns whether this directory view is enabled or not.
+func (element *Directory) Enabled () bool {
+	return element.enabled
+}
+
+// SetEnabled sets whether this directory view can be interacted with or not.
+func (element *Directory) SetEnabled (enabled bool) {
+	if element.enabled == enabled { return }
+	element.enabled = enabled
+	if element.table != nil { element.table.SetEnabled(enabled) }
+	element.entity.Invalidate()
+}
+
+// View returns how the directory view shows its files.
+func (element *Directory) View () DirectoryView {
+	return element.view
+}
+
+// SetView sets how the directory view shows its files. Switching between views
+// keeps the same files selected.
+func (element *Directory) SetView (view DirectoryView) {
+	if element.view == view { return }
+	selected := element.SelectedFiles()
+	element.sortColumn, element.descending = element.SortColumn()
+	element.view = view
+
+	element.DisownAll()
+	if view == DirectoryViewDetails {
+		if element.table == nil { element.table = element.newTable() }
+		element.Adopt(element.table)
+		element.table.SortBy(int(element.sortColumn), element.descending)
+	} else {
+		element.sortEntries()
+	}
+	element.showEntries()
+	element.selectFiles(selected...)
+}
+
+// SortColumn returns the column that files are sorted by, and whether they are
+// sorted in descending order.
+func (element *Directory) SortColumn () (column DirectoryColumn, descending bool) {
+	if element.view == DirectoryViewDetails {
+		// the user can change this by clicking on the table's header
+		tableColumn, descending := element.table.SortColumn()
+		return DirectoryColumn(tableColumn), descending
+	}
+	return element.sortColumn, element.descending
+}
+
+// SortBy sorts files by the specified column. By default, files are sorted by
+// name in ascending order.
+func (element *Directory) SortBy (column DirectoryColumn, descending bool) {
+	if column < DirectoryColumnName || column > DirectoryColumnPermissions {
+		return
+	}
+	element.sortColumn = column
+	element.descending = descending
+	if element.view == DirectoryViewDetails {
+		element.table.SortBy(int(column), descending)
+		return
+	}
+
+	selected := element.SelectedFiles()
+	element.sortEntries()
+	element.showEntries()
+	element.selectFiles(selected...)
+}
+
+// DirectoriesFirst returns whether directories are always shown before other
+// files, regardless of how they are sorted.
+func (element *Directory) DirectoriesFirst () bool {
+	return element.directoriesFirst
+}
+
+// SetDirectoriesFirst sets whether directories are always shown before other
+// files, regardless of how they are sorted. This is on by default.
+func (element *Directory) SetDirectoriesFirst (directoriesFirst bool) {
+	if element.directoriesFirst == directoriesFirst { return }
+	element.directoriesFirst = directoriesFirst
+	column, descending := element.SortColumn()
+	element.SortBy(column, descending)
+}
+
+// ShowHidden returns whether hidden files are shown.
+func (element *Directory) ShowHidden () bool {
+	return element.showHidden
+}
+
+// SetShowHidden sets whether hidden files, whose names start with a dot, are
+// shown. They are not shown by default.
+func (element *Directory) SetShowHidden (showHidden bool) error {
+	if element.showHidden == showHidden { return nil }
+	element.showHidden = showHidden
+	return element.Update()
+}
+
+func (element *Directory) HandleFocusChange () {
+	element.entity.Invalidate()
+}
+
+func (element *Directory) HandleChildScrollBoundsChange (child ability.Scrollable) {
+	if element.view == DirectoryViewDetails {
+		element.scrollBoundsChanged()
 	}
 }
 
@@ -124,14 +349,58 @@ func (element *Directory) HandleMouseDown  (
 	button input.Button,
 	modifiers input.Modifiers,
 ) {
-	element.selectNone()
+	if !element.enabled || element.view == DirectoryViewDetails { return }
+	element.Focus()
+	if button != input.ButtonLeft { return }
+
+	// holding down a modifier adds to the selection instead of replacing
+	// it
+	element.bandBase = make(map[tomo.Element] bool)
+	if modifiers.Control || modifiers.Shift {
+		for _, child := range element.items.selection() {
+			element.bandBase[child] = true
+		}
+	} else {
+		element.selector.selectNone()
+	}
+	if element.selector.mode == SelectionMultiple {
+		element.banding   = true
+		element.bandStart = element.contentPoint(position)
+		element.bandEnd   = element.bandStart
+	}
 }
 
 func (element *Directory) HandleMouseUp  (
 	position image.Point,
 	button input.Button,
 	modifiers input.Modifiers,
-) { }
+) {
+	if button != input.ButtonLeft || !element.banding { return }
+	element.banding  = false
+	element.bandBase = nil
+	if element.band == nil { return }
+
+	element.entity.Disown(element.entity.IndexOf(element.band))
+	element.band = nil
+}
+
+func (element *Directory) HandleMotion (position image.Point) {
+	if !element.banding { return }
+	element.bandEnd = element.contentPoint(position)
+	if element.band == nil {
+		element.band = newRubberBand()
+		element.entity.Adopt(element.band)
+	}
+	element.placeBand()
+
+	bounds := element.bandBounds()
+	element.selector.setSelection (func (index int) bool {
+		child := element.entity.Child(index)
+		if child == element.band { return false }
+		return element.bandBase[child] ||
+			child.Entity().Bounds().Overlaps(bounds)
+	})
+}
 
 func (element *Directory) HandleChildMouseDown  (
 	position image.Point,
@@ -139,10 +408,11 @@ func (element *Directory) HandleChildMouseDown  (
 	modifiers input.Modifiers,
 	child tomo.Element,
 ) {
-	element.selectNone()
-	if child, ok := child.(ability.Selectable); ok {
-		index := element.entity.IndexOf(child)
-		element.entity.SelectChild(index, true)
+	// the table takes care of its own selection
+	if !element.enabled || element.view == DirectoryViewDetails { return }
+	element.Focus()
+	if _, ok := child.(ability.Selectable); ok {
+		element.selector.pick(element.entity.IndexOf(child), modifiers, false)
 	}
 }
 
@@ -161,12 +431,18 @@ func (element *Directory) HandleChildFlexibleHeightChange (child ability.Flexibl
 
 // ScrollContentBounds returns the full content size of the element.
 func (element *Directory) ScrollContentBounds () image.Rectangle {
+	if element.view == DirectoryViewDetails {
+		return element.table.ScrollContentBounds()
+	}
 	return element.contentBounds
 }
 
 // ScrollViewportBounds returns the size and position of the element's
 // viewport relative to ScrollBounds.
 func (element *Directory) ScrollViewportBounds () image.Rectangle {
+	if element.view == DirectoryViewDetails {
+		return element.table.ScrollViewportBounds()
+	}
 	padding := element.entity.Theme().Padding(tomo.PatternPinboard, directoryCase)
 	bounds  := padding.Apply(element.entity.Bounds())
 	bounds   = bounds.Sub(bounds.Min).Add(element.scroll)
@@ -176,6 +452,10 @@ func (element *Directory) ScrollViewportBounds () image.Rectangle {
 // ScrollTo scrolls the viewport to the specified point relative to
 // ScrollBounds.
 func (element *Directory) ScrollTo (position image.Point) {
+	if element.view == DirectoryViewDetails {
+		element.table.ScrollTo(position)
+		return
+	}
 	if position.Y < 0 {
 		position.Y = 0
 	}
@@ -196,11 +476,20 @@ func (element *Directory) OnScrollBoundsChange (callback func ()) {
 
 // ScrollAxes returns the supported axes for scrolling.
 func (element *Directory) ScrollAxes () (horizontal, vertical bool) {
+	if element.view == DirectoryViewDetails {
+		return element.table.ScrollAxes()
+	}
 	return false, true
 }
 
+// ChildrenOverlap returns whether the directory's children might overlap each
+// other, which is the case while the rubber band is drawn on top of the files.
+func (element *Directory) ChildrenOverlap () bool {
+	return element.band != nil
+}
+
 func (element *Directory) DrawBackground (destination art.Canvas) {
-	element.entity.Theme().Pattern(tomo.PatternPinboard, tomo.State { }, directoryCase).
+	element.entity.Theme().Pattern(tomo.PatternPinboard, element.state(), directoryCase).
 		Draw(destination, element.entity.Bounds())
 }
 
@@ -262,21 +551,25 @@ func (element *Directory) Update () error {
 	location, filesystem := element.Location()
 	entries, err := filesystem.ReadDir(location)
 
-	children := make([]tomo.Element, len(entries))
-	for index, entry := range entries {
-		filePath := filepath.Join(location, entry.Name())
-		file, _ := NewFile(filePath, filesystem)
-		file.OnChoose (func () {
-			if element.onChoose != nil {
-				element.onChoose(filePath)
-			}
-		})
-		
-		children[index] = file
+	element.entries = nil
+	for _, entry := range entries {
+		item := directoryEntry {
+			name:     entry.Name(),
+			location: filepath.Join(location, entry.Name()),
+		}
+		if item.hidden() && !element.showHidden { continue }
+		// this comes from the ReadDir call when possible, so it doesn't
+		// need to be stat'd again
+		item.info, _ = entry.Info()
+		element.entries = append(element.entries, item)
 	}
+	element.sortEntries()
 
-	element.DisownAll()
-	element.Adopt(children...)
+	if element.view == DirectoryViewDetails {
+		element.table.Select()
+		element.table.ScrollTo(image.Point { })
+	}
+	element.showEntries()
 	return err
 }
 
@@ -286,10 +579,193 @@ func (element *Directory) OnChoose (callback func (file string)) {
 	element.onChoose = callback
 }
 
-func (element *Directory) selectNone () {
-	for index := 0; index < element.entity.CountChildren(); index ++ {
-		element.entity.SelectChild(index, false)
+func (element *Directory) HandleKeyDown (key input.Key, modifiers input.Modifiers) {
+	if !element.enabled { return }
+	cursor := element.items.cursorIndex()
+	index  := -1
+	switch key {
+	case input.KeyLeft:
+		index = cursor - 1
+	case input.KeyRight:
+		index = cursor + 1
+	case input.KeyUp:
+		if cursor >= 0 { index = element.gridNeighbor(cursor, -1) }
+	case input.KeyDown:
+		if cursor >= 0 {
+			index = element.gridNeighbor(cursor, 1)
+		} else {
+			index = 0
+		}
+	case ' ':
+		index = cursor
+	case 'a':
+		if modifiers.Control { element.selector.selectAll() }
+	case input.KeyEnter:
+		file, ok := element.items.cursor.(*File)
+		if ok {
+			location, _ := file.Location()
+			element.choose(location)
+		}
+	}
+	if index >= 0 && index < element.entity.CountChildren() {
+		element.selector.pick(index, modifiers, key != ' ')
+	}
+}
+
+func (element *Directory) HandleKeyUp (key input.Key, modifiers input.Modifiers) { }
+
+// gridNeighbor returns the index of the file in the next row of the grid above
+// (direction < 0) or below (direction > 0) the file at the specified index,
+// choosing the one that is horizontally closest to it. If there is no such
+// row, it returns -1.
+func (element *Directory) gridNeighbor (index, direction int) (neighbor int) {
+	abs := func (x int) int { if x < 0 { return -x }; return x }
+	current := element.entity.Child(index).Entity().Bounds()
+	center  := (current.Min.X + current.Max.X) / 2
+
+	neighbor = -1
+	rowDistance, columnDistance := 0, 0
+	for candidate := 0; candidate < element.entity.CountChildren(); candidate ++ {
+		child := element.entity.Child(candidate)
+		if child == element.band { continue }
+		bounds := child.Entity().Bounds()
+
+		// files in the same row all start at the same height
+		rowOffset := (bounds.Min.Y - current.Min.Y) * direction
+		if rowOffset <= 0 { continue }
+		columnOffset := abs((bounds.Min.X + bounds.Max.X) / 2 - center)
+
+		closer := neighbor < 0 ||
+			rowOffset < rowDistance ||
+			rowOffset == rowDistance && columnOffset < columnDistance
+		if closer {
+			neighbor       = candidate
+			rowDistance    = rowOffset
+			columnDistance = columnOffset
+		}
+	}
+	return
+}
+
+// sortEntries puts the entries in the order they are shown in the icon view.
+func (element *Directory) sortEntries () {
+	column     := element.sortColumn
+	descending := element.descending
+	sort.SliceStable(element.entries, func (i, j int) bool {
+		a, b := element.entries[i], element.entries[j]
+		groupA, groupB := element.group(a), element.group(b)
+		if groupA != groupB { return groupA < groupB }
+		if descending { a, b = b, a }
+		return a.less(column, b)
+	})
+}
+
+// group returns the group an entry is sorted within. Groups are kept in order
+// regardless of how the entries are sorted, which is how directories are shown
+// first.
+func (element *Directory) group (entry directoryEntry) int {
+	if element.directoriesFirst && !entry.isDir() { return 1 }
+	return 0
+}
+
+// showEntries creates a file element for each entry, or refreshes the table if
+// the entries are shown in the details view.
+func (element *Directory) showEntries () {
+	if element.view == DirectoryViewDetails {
+		element.table.Refresh()
+		return
+	}
+
+	_, filesystem := element.Location()
+	children := make([]tomo.Element, len(element.entries))
+	for index, entry := range element.entries {
+		location := entry.location
+		file, _ := NewFile(location, filesystem)
+		file.OnChoose (func () {
+			element.choose(location)
+		})
+		children[index] = file
+	}
+
+	element.DisownAll()
+	element.Adopt(children...)
+}
+
+// selectFiles selects the files at the specified locations, deselecting all
+// others.
+func (element *Directory) selectFiles (locations ...string) {
+	wanted := make(map[string] bool)
+	for _, location := range locations {
+		wanted[location] = true
+	}
+
+	if element.view == DirectoryViewDetails {
+		rows := []int { }
+		for row, entry := range element.entries {
+			if wanted[entry.location] { rows = append(rows, row) }
+		}
+		element.table.Select(rows...)
+		return
 	}
+	element.selector.setSelection (func (index int) bool {
+		return wanted[element.entries[index].location]
+	})
+}
+
+func (element *Directory) newTable () (table *Table) {
+	table = NewTable(directoryTable { directory: element })
+	table.SetSelectionMode(element.selector.mode)
+	table.SetEnabled(element.enabled)
+	// the directory view is scrolled from the outside, so the table
+	// shouldn't ask for enough room to show all of its rows and columns
+	table.Collapse(1, 1)
+	table.OnSelectionChange (func () {
+		if element.selector.onSelectionChange != nil {
+			element.selector.onSelectionChange()
+		}
+	})
+	table.OnActivate (func (row int) {
+		element.choose(element.entries[row].location)
+	})
+	return
+}
+
+func (element *Directory) choose (location string) {
+	if element.onChoose != nil {
+		element.onChoose(location)
+	}
+}
+
+func (element *Directory) scrollBoundsChanged () {
+	element.entity.NotifyScrollBoundsChange()
+	if element.onScrollBoundsChange != nil {
+		element.onScrollBoundsChange()
+	}
+}
+
+// contentPoint converts a point on screen to a point relative to the content,
+// which stays the same as the directory view is scrolled.
+func (element *Directory) contentPoint (point image.Point) image.Point {
+	padding := element.entity.Theme().Padding(tomo.PatternPinboard, directoryCase)
+	inner   := padding.Apply(element.entity.Bounds())
+	return point.Sub(inner.Min).Add(element.scroll)
+}
+
+// bandBounds returns the on-screen bounds of the rubber band selection.
+func (element *Directory) bandBounds () image.Rectangle {
+	padding := element.entity.Theme().Padding(tomo.PatternPinboard, directoryCase)
+	inner   := padding.Apply(element.entity.Bounds())
+	return image.Rectangle {
+		Min: element.bandStart,
+		Max: element.bandEnd,
+	}.Canon().Add(inner.Min).Sub(element.scroll)
+}
+
+func (element *Directory) placeBand () {
+	if element.band == nil { return }
+	element.entity.PlaceChild (
+		element.entity.IndexOf(element.band),
+		element.bandBounds())
 }
 
 func (element *Directory) maxScrollHeight () (height int) {
@@ -301,10 +777,18 @@ func (element *Directory) maxScrollHeight () (height int) {
 }
 
 
+func (element *Directory) state () tomo.State {
+	return tomo.State {
+		Focused:  element.entity.Focused(),
+		Disabled: !element.enabled,
+	}
+}
+
 func (element *Directory) updateMinimumSize () {
 	padding := element.entity.Theme().Padding(tomo.PatternPinboard, directoryCase)
 	minimumWidth := 0
 	for index := 0; index < element.entity.CountChildren(); index ++ {
+		if element.entity.Child(index) == element.band { continue }
 		width, height := element.entity.ChildMinimumSize(index)
 		if width > minimumWidth {
 			minimumWidth = width
@@ -320,3 +804,29 @@ func (element *Directory) updateMinimumSize () {
 		minimumWidth + padding.Horizontal(),
 		padding.Vertical())
 }
+
+// rubberBand is the outline drawn around the area that the user is dragging
+// out in order to select files.
+type rubberBand struct {
+	entity tomo.Entity
+}
+
+func newRubberBand () (element *rubberBand) {
+	element = &rubberBand { }
+	element.entity = tomo.GetBackend().NewEntity(element)
+	return
+}
+
+func (element *rubberBand) Entity () tomo.Entity {
+	return element.entity
+}
+
+func (element *rubberBand) Draw (destination art.Canvas) {
+	theme   := element.entity.Theme()
+	bounds  := element.entity.Bounds()
+	padding := theme.Padding(tomo.PatternLine, rubberBandCase)
+	pattern := theme.Pattern(tomo.PatternLine, tomo.State { }, rubberBandCase)
+	for _, edge := range shatter.Shatter(bounds, padding.Apply(bounds)) {
+		pattern.Draw(destination, edge)
+	}
+}
@@ -0,0 +1,167 @@
+package elements
+
+import "fmt"
+import "mime"
+import "io/fs"
+import "strings"
+import "path/filepath"
+
+// DirectoryView determines how a directory view shows its files.
+type DirectoryView int; const (
+	// DirectoryViewIcons shows files as a grid of icons.
+	DirectoryViewIcons DirectoryView = iota
+
+	// DirectoryViewDetails shows files as rows in a table, with a column
+	// for each piece of information about them.
+	DirectoryViewDetails
+)
+
+// DirectoryColumn is a piece of information about a file that a directory view
+// can show and sort by.
+type DirectoryColumn int; const (
+	DirectoryColumnName DirectoryColumn = iota
+	DirectoryColumnSize
+	DirectoryColumnModified
+	DirectoryColumnType
+	DirectoryColumnPermissions
+)
+
+// String returns a human-readable name for the column.
+func (column DirectoryColumn) String () string {
+	switch column {
+	case DirectoryColumnName:        return "Name"
+	case DirectoryColumnSize:        return "Size"
+	case DirectoryColumnModified:    return "Modified"
+	case DirectoryColumnType:        return "Type"
+	case DirectoryColumnPermissions: return "Permissions"
+	default:                         return "Unknown"
+	}
+}
+
+// directoryEntry is a file within the directory a directory view is showing.
+// If the file could not be stat'd, info is nil.
+type directoryEntry struct {
+	name     string
+	location string
+	info     fs.FileInfo
+}
+
+func (entry directoryEntry) isDir () bool {
+	return entry.info != nil && entry.info.IsDir()
+}
+
+func (entry directoryEntry) hidden () bool {
+	return strings.HasPrefix(entry.name, ".")
+}
+
+func (entry directoryEntry) cell (column DirectoryColumn) string {
+	if column == DirectoryColumnName { return entry.name }
+	if entry.info == nil { return "" }
+
+	switch column {
+	case DirectoryColumnSize:
+		if entry.info.IsDir() { return "" }
+		return formatSize(entry.info.Size())
+	case DirectoryColumnModified:
+		// some file systems such as embed.FS don't keep track of this
+		if entry.info.ModTime().IsZero() { return "" }
+		return entry.info.ModTime().Format("2006-01-02 15:04")
+	case DirectoryColumnType:
+		return entry.kind()
+	case DirectoryColumnPermissions:
+		return entry.info.Mode().String()
+	}
+	return ""
+}
+
+// kind returns a description of what sort of file the entry is.
+func (entry directoryEntry) kind () string {
+	mode := entry.info.Mode()
+	switch {
+	case mode.IsDir():                 return "Directory"
+	case mode & fs.ModeSymlink != 0:   return "Symbolic link"
+	case mode & fs.ModeNamedPipe != 0: return "Named pipe"
+	case mode & fs.ModeSocket != 0:    return "Socket"
+	case mode & fs.ModeDevice != 0:    return "Device"
+	}
+
+	kind := mime.TypeByExtension(filepath.Ext(entry.name))
+	if index := strings.IndexByte(kind, ';'); index >= 0 {
+		kind = kind[:index]
+	}
+	if kind == "" { kind = "File" }
+	return kind
+}
+
+// less reports whether a should come before b when sorted by the specified
+// column. Files that are the same as far as the column is concerned are sorted
+// by name.
+func (entry directoryEntry) less (column DirectoryColumn, other directoryEntry) bool {
+	if entry.info != nil && other.info != nil {
+		switch column {
+		case DirectoryColumnSize:
+			if entry.info.Size() != other.info.Size() {
+				return entry.info.Size() < other.info.Size()
+			}
+		case DirectoryColumnModified:
+			a, b := entry.info.ModTime(), other.info.ModTime()
+			if !a.Equal(b) { return a.Before(b) }
+		case DirectoryColumnType:
+			a, b := entry.kind(), other.kind()
+			if a != b { return a < b }
+		case DirectoryColumnPermissions:
+			a, b := entry.info.Mode(), other.info.Mode()
+			if a != b { return a < b }
+		}
+	}
+
+	a, b := strings.ToLower(entry.name), strings.ToLower(other.name)
+	if a != b { return a < b }
+	return entry.name < other.name
+}
+
+// directoryTable is the model used by a directory view to show its files in a
+// table.
+type directoryTable struct {
+	directory *Directory
+}
+
+func (directoryTable) Columns () int {
+	return int(DirectoryColumnPermissions) + 1
+}
+
+func (directoryTable) ColumnName (column int) string {
+	return DirectoryColumn(column).String()
+}
+
+func (model directoryTable) Rows () int {
+	return len(model.directory.entries)
+}
+
+func (model directoryTable) Cell (row, column int) string {
+	return model.directory.entries[row].cell(DirectoryColumn(column))
+}
+
+func (model directoryTable) Less (column, a, b int) bool {
+	return model.directory.entries[a].less (
+		DirectoryColumn(column),
+		model.directory.entries[b])
+}
+
+func (model directoryTable) Group (row int) int {
+	return model.directory.group(model.directory.entries[row])
+}
+
+// formatSize formats a file size in bytes so that it is easy to read.
+func formatSize (size int64) string {
+	const unit = 1024
+	if size < unit { return fmt.Sprint(size, " B") }
+	value := float64(size) / unit
+	for _, prefix := range "KMGTPE" {
+		if value < unit || prefix == 'E' {
+			return fmt.Sprintf("%.1f %ciB", value, prefix)
+		}
+		value /= unit
+	}
+	return ""
+}
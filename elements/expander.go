@@ -0,0 +1,406 @@
+package elements
+
+import "time"
+import "image"
+import "tomo"
+import "tomo/input"
+import "art"
+import "art/shatter"
+import "tomo/ability"
+import "tomo/textdraw"
+
+var expanderCase       = tomo.C("tomo", "expander")
+var expanderHeaderCase = tomo.C("tomo", "expander", "header")
+
+// Expander is a container with a clickable header that shows or hides its
+// child. When it is expanded or collapsed, its height changes smoothly over a
+// short period of time if the backend supports animation.
+type Expander struct {
+	entity tomo.Entity
+	drawer textdraw.Drawer
+
+	child    tomo.Element
+	header   image.Rectangle
+	expanded bool
+	animated bool
+	enabled  bool
+	pressed  bool
+
+	label labelText
+
+	// progress is how much of the child is revealed, from zero to one
+	progress  float64
+	animation animation
+
+	onToggle func ()
+}
+
+// NewExpander creates a new collapsed expander with the specified header text
+// and child.
+func NewExpander (text string, child tomo.Element) (element *Expander) {
+	element = &Expander { enabled: true, animated: true }
+	element.entity = tomo.GetBackend().NewEntity(element)
+	element.updateFaces()
+	element.SetText(text)
+	element.Adopt(child)
+	return
+}
+
+// Entity returns this element's entity.
+func (element *Expander) Entity () tomo.Entity {
+	return element.entity
+}
+
+// Draw causes the element to draw to the specified destination canvas.
+func (element *Expander) Draw (destination art.Canvas) {
+	if element.animating() {
+		// the animation stops when the expander is taken out of its
+		// window, so it must be started again if it is put back
+		element.animation.start (
+			element.entity, time.Second / 60,
+			element.step)
+	}
+
+	rocks := []image.Rectangle { element.header }
+	if element.childShown() {
+		rocks = append(rocks, element.child.Entity().Bounds())
+	}
+	tiles := shatter.Shatter(element.entity.Bounds(), rocks...)
+	for _, tile := range tiles {
+		element.entity.DrawBackground(art.Cut(destination, tile))
+	}
+
+	theme   := element.entity.Theme()
+	state   := element.state()
+	padding := theme.Padding(tomo.PatternButton, expanderHeaderCase)
+	margin  := theme.Margin(tomo.PatternButton, expanderHeaderCase)
+	theme.Pattern(tomo.PatternButton, state, expanderHeaderCase).
+		Draw(destination, element.header)
+	foreground := theme.Color(tomo.ColorForeground, state, expanderHeaderCase)
+	inner := padding.Apply(element.header)
+	x := inner.Min.X
+
+	if icon := element.icon(); icon != nil {
+		iconBounds := icon.Bounds()
+		icon.Draw(destination, foreground, image.Pt (
+			x, inner.Min.Y + (inner.Dy() - iconBounds.Dy()) / 2))
+		x += iconBounds.Dx() + margin.X
+	}
+
+	textBounds := element.drawer.LayoutBounds()
+	offset := image.Pt (
+		x,
+		inner.Min.Y + (inner.Dy() - textBounds.Dy()) / 2).Sub(textBounds.Min)
+	element.drawer.Draw(destination, foreground, offset)
+	element.label.underline(element.drawer, destination, foreground, offset)
+}
+
+// Layout causes this element to perform a layout operation.
+func (element *Expander) Layout () {
+	bounds := element.entity.Bounds()
+	element.header = bounds
+	element.header.Max.Y = bounds.Min.Y + element.headerHeight()
+
+	if !element.childShown() { return }
+
+	// the child is always given its full height. while animating, the
+	// part of it that doesn't fit is cut off.
+	margin := element.entity.Theme().Margin(tomo.PatternBackground, expanderCase)
+	top := element.header.Max.Y + margin.Y
+	element.entity.PlaceChild (
+		element.entity.IndexOf(element.child),
+		image.Rect (
+			bounds.Min.X, top,
+			bounds.Max.X, top + element.childHeight(bounds.Dx())))
+}
+
+// DrawBackground draws this element's background pattern to the specified
+// destination canvas.
+func (element *Expander) DrawBackground (destination art.Canvas) {
+	element.entity.DrawBackground(destination)
+}
+
+// Adopt sets this element's child. If nil is passed, any child is removed.
+func (element *Expander) Adopt (child tomo.Element) {
+	if element.childShown() {
+		element.entity.Disown(element.entity.IndexOf(element.child))
+	}
+	element.child = child
+	if element.child != nil && element.progress > 0 {
+		element.entity.Adopt(element.child)
+	}
+	element.changed()
+}
+
+// Child returns this element's child. If there is no child, this method will
+// return nil.
+func (element *Expander) Child () tomo.Element {
+	return element.child
+}
+
+// SetText sets the text displayed in the header.
+func (element *Expander) SetText (text string) {
+	element.setText(text, false)
+}
+
+// SetTextWithMnemonic is like SetText, but an underscore marks the character
+// after it as the mnemonic, which can be pressed along with Alt to expand or
+// collapse it. Use two underscores to display a literal underscore.
+func (element *Expander) SetTextWithMnemonic (text string) {
+	element.setText(text, true)
+}
+
+func (element *Expander) setText (text string, parse bool) {
+	display, changed := element.label.set(text, parse)
+	if !changed { return }
+	element.drawer.SetText(display)
+	element.changed()
+}
+
+// Expanded returns whether the expander is expanded.
+func (element *Expander) Expanded () bool {
+	return element.expanded
+}
+
+// SetExpanded sets whether the expander is expanded, showing its child.
+func (element *Expander) SetExpanded (expanded bool) {
+	if element.expanded == expanded { return }
+	element.expanded = expanded
+
+	if element.child != nil && !element.childShown() {
+		element.entity.Adopt(element.child)
+	}
+	animating := element.animated && element.animation.start (
+		element.entity, time.Second / 60,
+		element.step)
+	if !animating {
+		element.finish()
+	}
+	element.changed()
+	if element.onToggle != nil {
+		element.onToggle()
+	}
+}
+
+// SetAnimated sets whether the expander changes its height smoothly when it is
+// expanded or collapsed. It is animated by default.
+func (element *Expander) SetAnimated (animated bool) {
+	element.animated = animated
+	if !animated && element.animation.running() {
+		element.finish()
+		element.changed()
+	}
+}
+
+// OnToggle sets a function to be called when the expander is expanded or
+// collapsed.
+func (element *Expander) OnToggle (callback func ()) {
+	element.onToggle = callback
+}
+
+// Focus gives this element input focus.
+func (element *Expander) Focus () {
+	if !element.entity.Focused() { element.entity.Focus() }
+}
+
+// Enabled returns whether this expander can be toggled.
+func (element *Expander) Enabled () bool {
+	return element.enabled
+}
+
+// SetEnabled sets whether this expander can be toggled.
+func (element *Expander) SetEnabled (enabled bool) {
+	if element.enabled == enabled { return }
+	element.enabled = enabled
+	element.entity.Invalidate()
+}
+
+func (element *Expander) Mnemonic () rune {
+	return element.label.mnemonic
+}
+
+func (element *Expander) HandleMnemonic () {
+	element.Focus()
+	element.SetExpanded(!element.expanded)
+}
+
+func (element *Expander) HandleFocusChange () {
+	element.entity.Invalidate()
+}
+
+func (element *Expander) HandleMouseDown (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) {
+	if !element.Enabled() || !position.In(element.header) { return }
+	element.Focus()
+	if button != input.ButtonLeft { return }
+	element.pressed = true
+	element.entity.Invalidate()
+}
+
+func (element *Expander) HandleMouseUp (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) {
+	if button != input.ButtonLeft || !element.pressed { return }
+	element.pressed = false
+	element.entity.Invalidate()
+	if element.Enabled() && position.In(element.header) {
+		element.SetExpanded(!element.expanded)
+	}
+}
+
+func (element *Expander) HandleKeyDown (key input.Key, modifiers input.Modifiers) {
+	if !element.Enabled() { return }
+	switch key {
+	case input.KeyEnter, ' ':
+		element.SetExpanded(!element.expanded)
+	case input.KeyRight:
+		element.SetExpanded(true)
+	case input.KeyLeft:
+		element.SetExpanded(false)
+	}
+}
+
+func (element *Expander) HandleKeyUp (key input.Key, modifiers input.Modifiers) { }
+
+// FlexibleHeightFor returns the reccomended height for this element based on
+// the given width, taking into account the flexible height of its child.
+func (element *Expander) FlexibleHeightFor (width int) int {
+	return element.headerHeight() + element.revealHeight(width)
+}
+
+func (element *Expander) HandleChildMinimumSizeChange (child tomo.Element) {
+	element.changed()
+}
+
+func (element *Expander) HandleChildFlexibleHeightChange (child ability.Flexible) {
+	element.changed()
+}
+
+func (element *Expander) HandleThemeChange () {
+	element.updateFaces()
+	element.changed()
+}
+
+func (element *Expander) step () {
+	if element.expanded {
+		element.progress += 1.0 / 10
+	} else {
+		element.progress -= 1.0 / 10
+	}
+	if element.progress <= 0 || element.progress >= 1 {
+		element.finish()
+	}
+	element.changed()
+}
+
+// finish stops any animation, and jumps to the end of it.
+func (element *Expander) finish () {
+	element.animation.stop()
+	if element.expanded {
+		element.progress = 1
+	} else {
+		element.progress = 0
+		if element.childShown() {
+			element.entity.Disown(element.entity.IndexOf(element.child))
+		}
+	}
+}
+
+// animating returns whether the expander is partway through expanding or
+// collapsing.
+func (element *Expander) animating () bool {
+	if element.expanded {
+		return element.progress < 1
+	} else {
+		return element.progress > 0
+	}
+}
+
+func (element *Expander) childShown () bool {
+	return element.child != nil && element.entity.IndexOf(element.child) >= 0
+}
+
+// childHeight returns the full height of the child at the specified width. If
+// the width is zero, the minimum height of the child is returned.
+func (element *Expander) childHeight (width int) int {
+	if !element.childShown() { return 0 }
+	_, height := element.entity.ChildMinimumSize(element.entity.IndexOf(element.child))
+	if child, ok := element.child.(ability.Flexible); ok && width > 0 {
+		flexibleHeight := child.FlexibleHeightFor(width)
+		if flexibleHeight > height { height = flexibleHeight }
+	}
+	return height
+}
+
+// revealHeight returns how much space below the header is currently taken up
+// by the child.
+func (element *Expander) revealHeight (width int) int {
+	if !element.childShown() { return 0 }
+	margin := element.entity.Theme().Margin(tomo.PatternBackground, expanderCase)
+	full := margin.Y + element.childHeight(width)
+	return int(element.progress * float64(full))
+}
+
+func (element *Expander) headerHeight () int {
+	padding := element.entity.Theme().Padding(tomo.PatternButton, expanderHeaderCase)
+	height := element.drawer.LayoutBounds().Dy()
+	if icon := element.icon(); icon != nil && icon.Bounds().Dy() > height {
+		height = icon.Bounds().Dy()
+	}
+	return height + padding.Vertical()
+}
+
+func (element *Expander) icon () art.Icon {
+	id := tomo.IconForward
+	if element.expanded { id = tomo.IconExpand }
+	return element.entity.Theme().Icon(id, tomo.IconSizeSmall, expanderHeaderCase)
+}
+
+func (element *Expander) changed () {
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+func (element *Expander) updateMinimumSize () {
+	padding := element.entity.Theme().Padding(tomo.PatternButton, expanderHeaderCase)
+	margin  := element.entity.Theme().Margin(tomo.PatternButton, expanderHeaderCase)
+
+	width := element.drawer.LayoutBounds().Dx()
+	if icon := element.icon(); icon != nil {
+		width += icon.Bounds().Dx() + margin.X
+	}
+	width += padding.Horizontal()
+	height := element.headerHeight()
+
+	if element.childShown() {
+		childWidth, _ := element.entity.ChildMinimumSize (
+			element.entity.IndexOf(element.child))
+		if childWidth > width { width = childWidth }
+		height += element.revealHeight(0)
+	}
+
+	element.entity.SetMinimumSize(width, height)
+	element.entity.NotifyFlexibleHeightChange()
+}
+
+func (element *Expander) updateFaces () {
+	element.drawer.SetFace (element.entity.Theme().FontFace (
+		tomo.FontStyleRegular,
+		tomo.FontSizeNormal,
+		expanderHeaderCase))
+}
+
+func (element *Expander) state () tomo.State {
+	return tomo.State {
+		Disabled: !element.Enabled(),
+		Focused:  element.entity.Focused(),
+		Pressed:  element.pressed,
+		On:       element.expanded,
+	}
+}
@@ -0,0 +1,62 @@
+package elements
+
+import "tomo"
+
+var formHeadingCase = tomo.C("tomo", "form", "heading")
+
+// Form is a container that lays out fields next to labels describing them. The
+// labels are aligned to the right, in a column as wide as the widest label,
+// and the fields take up the rest of the width. Clicking on a label, or
+// pressing its mnemonic while holding Alt, focuses its field. Since a Form is a
+// Grid with two columns, elements can also be placed in it freely.
+type Form struct {
+	Grid
+}
+
+// NewForm creates a new, empty form.
+func NewForm (space Space) (element *Form) {
+	element = &Form { }
+	element.entity = tomo.GetBackend().NewEntity(element)
+	element.construct(space, []Track { { }, { Weight: 1 } })
+	return
+}
+
+// AddField adds a row to the bottom of the form, containing a field and a label
+// describing it. An underscore in the label text marks the character after it
+// as the mnemonic, which can be pressed along with Alt to focus the field. Use
+// two underscores to display a literal underscore.
+func (element *Form) AddField (label string, field tomo.Element) {
+	row := element.nextRow()
+	labelElement := NewLabel("")
+	labelElement.SetTextWithMnemonic(label)
+	labelElement.SetTarget(field)
+	element.Attach(labelElement, 0, row, 1, 1)
+	element.SetAlign(labelElement, AlignEnd, AlignMiddle)
+	element.Attach(field, 1, row, 1, 1)
+}
+
+// AddHeading adds a heading to the bottom of the form, which can be used to
+// separate it into sections. Headings are labels drawn with their own case, so
+// that themes can style them differently.
+func (element *Form) AddHeading (text string) {
+	heading := NewLabel(text)
+	heading.setCase(formHeadingCase)
+	element.Attach(heading, 0, element.nextRow(), 2, 1)
+	element.SetAlign(heading, AlignStart, AlignEnd)
+}
+
+// AddRow adds an element to the bottom of the form that takes up its entire
+// width.
+func (element *Form) AddRow (child tomo.Element) {
+	element.Attach(child, 0, element.nextRow(), 2, 1)
+}
+
+// nextRow returns the index of the first row below every cell.
+func (element *Form) nextRow () (row int) {
+	for _, cell := range element.cells {
+		if cell.row + cell.rowSpan > row {
+			row = cell.row + cell.rowSpan
+		}
+	}
+	return
+}
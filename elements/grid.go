@@ -0,0 +1,433 @@
+package elements
+
+import "image"
+import "tomo"
+import "art"
+import "art/shatter"
+
+var gridCase = tomo.C("tomo", "grid")
+
+// Align specifies how an element is positioned within an area that is larger
+// than it along one axis.
+type Align int; const (
+	// AlignFill stretches the element to fill the entire area.
+	AlignFill Align = iota
+
+	// AlignStart places the element at the left or top of the area.
+	AlignStart
+
+	// AlignMiddle places the element in the center of the area.
+	AlignMiddle
+
+	// AlignEnd places the element at the right or bottom of the area.
+	AlignEnd
+)
+
+// Track specifies how a row or column of a Grid is sized. The zero value is a
+// track that is as large as the largest minimum size of the cells within it.
+type Track struct {
+	// Size, if greater than zero, fixes the size of the track regardless of
+	// the cells within it.
+	Size int
+
+	// Weight, if greater than zero, causes the track to expand to fill any
+	// space left over in the grid. The space is split between expanding
+	// tracks in proportion to their weights.
+	Weight float64
+}
+
+type gridCell struct {
+	column, row         int
+	columnSpan, rowSpan int
+	horizontal          Align
+	vertical            Align
+}
+
+// Grid is a container that lays out its children in rows and columns. Each
+// child occupies a cell, and may span multiple rows or columns. The size of
+// each row and column is controlled by a Track.
+type Grid struct {
+	container
+	padding bool
+	margin  bool
+
+	columns []Track
+	rows    []Track
+	cells   map[tomo.Element] gridCell
+
+	// columnTracks and rowTracks are the tracks set by the user, followed
+	// by as many implicit tracks as are needed to hold every cell.
+	columnTracks []Track
+	rowTracks    []Track
+
+	columnMinimums []int
+	rowMinimums    []int
+}
+
+// NewGrid creates a new grid with the specified columns. Rows are added as
+// needed, and are sized to fit their contents by default.
+func NewGrid (space Space, columns ...Track) (element *Grid) {
+	element = &Grid { }
+	element.entity = tomo.GetBackend().NewEntity(element)
+	element.construct(space, columns)
+	return
+}
+
+func (element *Grid) construct (space Space, columns []Track) {
+	element.padding = space.Includes(SpacePadding)
+	element.margin  = space.Includes(SpaceMargin)
+	element.columns = append([]Track(nil), columns...)
+	element.cells   = make(map[tomo.Element] gridCell)
+	element.minimumSize = element.updateMinimumSize
+	element.init()
+}
+
+// Draw causes the element to draw to the specified destination canvas.
+func (element *Grid) Draw (destination art.Canvas) {
+	rocks := make([]image.Rectangle, element.entity.CountChildren())
+	for index := 0; index < element.entity.CountChildren(); index ++ {
+		rocks[index] = element.entity.Child(index).Entity().Bounds()
+	}
+
+	tiles := shatter.Shatter(element.entity.Bounds(), rocks...)
+	for _, tile := range tiles {
+		element.entity.DrawBackground(art.Cut(destination, tile))
+	}
+}
+
+// Layout causes this element to perform a layout operation.
+func (element *Grid) Layout () {
+	margin := element.marginSize()
+	bounds := element.entity.Bounds()
+	if element.padding {
+		padding := element.entity.Theme().Padding(tomo.PatternBackground, gridCase)
+		bounds = padding.Apply(bounds)
+	}
+
+	columns := distributeTracks (
+		element.columnTracks, element.columnMinimums,
+		bounds.Dx(), margin.X)
+	rows := distributeTracks (
+		element.rowTracks, element.rowMinimums,
+		bounds.Dy(), margin.Y)
+	columnStarts := trackStarts(columns, bounds.Min.X, margin.X)
+	rowStarts    := trackStarts(rows,    bounds.Min.Y, margin.Y)
+
+	for index := 0; index < element.entity.CountChildren(); index ++ {
+		cell := element.cells[element.entity.Child(index)]
+		lastColumn := cell.column + cell.columnSpan - 1
+		lastRow    := cell.row    + cell.rowSpan    - 1
+		area := image.Rect (
+			columnStarts[cell.column],
+			rowStarts[cell.row],
+			columnStarts[lastColumn] + columns[lastColumn],
+			rowStarts[lastRow]       + rows[lastRow])
+
+		width, height := element.entity.ChildMinimumSize(index)
+		area.Min.X, area.Max.X = alignSpan (
+			cell.horizontal, area.Min.X, area.Max.X, width)
+		area.Min.Y, area.Max.Y = alignSpan (
+			cell.vertical, area.Min.Y, area.Max.Y, height)
+		element.entity.PlaceChild(index, area)
+	}
+}
+
+// Adopt adds one or more elements to the grid. Each one is placed in the next
+// free cell, going left to right and then top to bottom.
+func (element *Grid) Adopt (children ...tomo.Element) {
+	element.updateTracks()
+	columnCount := len(element.columnTracks)
+	if columnCount < 1 { columnCount = 1 }
+
+	occupied := make(map[image.Point] bool)
+	last := image.Pt(-1, 0)
+	for _, cell := range element.cells {
+		for row := cell.row; row < cell.row + cell.rowSpan; row ++ {
+		for column := cell.column; column < cell.column + cell.columnSpan; column ++ {
+			occupied[image.Pt(column, row)] = true
+			if row > last.Y || (row == last.Y && column > last.X) {
+				last = image.Pt(column, row)
+			}
+		}}
+	}
+
+	for _, child := range children {
+		for {
+			last.X ++
+			if last.X >= columnCount { last.X = 0; last.Y ++ }
+			if !occupied[last] { break }
+		}
+		element.Attach(child, last.X, last.Y, 1, 1)
+	}
+}
+
+// Attach adds an element to the grid at the specified column and row. The
+// element takes up the specified number of columns and rows, which must be at
+// least one. Rows and columns are added to the grid as needed.
+func (element *Grid) Attach (
+	child tomo.Element,
+	column, row int,
+	columnSpan, rowSpan int,
+) {
+	if column     < 0 { column     = 0 }
+	if row        < 0 { row        = 0 }
+	if columnSpan < 1 { columnSpan = 1 }
+	if rowSpan    < 1 { rowSpan    = 1 }
+
+	cell := gridCell {
+		column:     column,
+		row:        row,
+		columnSpan: columnSpan,
+		rowSpan:    rowSpan,
+	}
+	if existing, ok := element.cells[child]; ok {
+		cell.horizontal = existing.horizontal
+		cell.vertical   = existing.vertical
+		element.cells[child] = cell
+		element.minimumSize()
+		element.entity.Invalidate()
+		element.entity.InvalidateLayout()
+		return
+	}
+	element.cells[child] = cell
+	element.container.Adopt(child)
+}
+
+// SetAlign sets how an element is positioned within its cell. By default,
+// elements fill their cells.
+func (element *Grid) SetAlign (child tomo.Element, horizontal, vertical Align) {
+	cell, ok := element.cells[child]
+	if !ok { return }
+	cell.horizontal = horizontal
+	cell.vertical   = vertical
+	element.cells[child] = cell
+	element.entity.InvalidateLayout()
+}
+
+// Disown removes one or more elements from the grid.
+func (element *Grid) Disown (children ...tomo.Element) {
+	for _, child := range children {
+		delete(element.cells, child)
+	}
+	element.container.Disown(children...)
+}
+
+// DisownAll removes all elements from the grid.
+func (element *Grid) DisownAll () {
+	element.cells = make(map[tomo.Element] gridCell)
+	element.container.DisownAll()
+}
+
+// SetColumns sets the tracks used to size each column. If there are cells
+// beyond the last track, they are placed in implicit columns that are sized to
+// fit their contents.
+func (element *Grid) SetColumns (columns ...Track) {
+	element.columns = append([]Track(nil), columns...)
+	element.minimumSize()
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+// SetRows sets the tracks used to size each row. If there are cells beyond the
+// last track, they are placed in implicit rows that are sized to fit their
+// contents.
+func (element *Grid) SetRows (rows ...Track) {
+	element.rows = append([]Track(nil), rows...)
+	element.minimumSize()
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+// Columns returns the tracks set with SetColumns, not including implicit ones.
+func (element *Grid) Columns () []Track {
+	return append([]Track(nil), element.columns...)
+}
+
+// Rows returns the tracks set with SetRows, not including implicit ones.
+func (element *Grid) Rows () []Track {
+	return append([]Track(nil), element.rows...)
+}
+
+// DrawBackground draws this element's background pattern to the specified
+// destination canvas.
+func (element *Grid) DrawBackground (destination art.Canvas) {
+	element.entity.DrawBackground(destination)
+}
+
+func (element *Grid) HandleThemeChange () {
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+func (element *Grid) marginSize () image.Point {
+	if !element.margin { return image.Point { } }
+	return element.entity.Theme().Margin(tomo.PatternBackground, gridCase)
+}
+
+// updateTracks recalculates the implicit tracks needed to hold every cell, so
+// that they shrink again once the cells beyond the last set track are gone.
+func (element *Grid) updateTracks () {
+	columnCount, rowCount := len(element.columns), len(element.rows)
+	for _, cell := range element.cells {
+		if end := cell.column + cell.columnSpan; end > columnCount {
+			columnCount = end
+		}
+		if end := cell.row + cell.rowSpan; end > rowCount {
+			rowCount = end
+		}
+	}
+	element.columnTracks = extendTracks(element.columns, columnCount)
+	element.rowTracks    = extendTracks(element.rows,    rowCount)
+}
+
+func (element *Grid) updateMinimumSize () {
+	element.updateTracks()
+	margin := element.marginSize()
+
+	columnCells := make([]trackCell, 0, len(element.cells))
+	rowCells    := make([]trackCell, 0, len(element.cells))
+	for index := 0; index < element.entity.CountChildren(); index ++ {
+		cell := element.cells[element.entity.Child(index)]
+		width, height := element.entity.ChildMinimumSize(index)
+		columnCells = append(columnCells, trackCell {
+			start: cell.column, span: cell.columnSpan, size: width,
+		})
+		rowCells = append(rowCells, trackCell {
+			start: cell.row, span: cell.rowSpan, size: height,
+		})
+	}
+	element.columnMinimums = minimumTracks(element.columnTracks, columnCells, margin.X)
+	element.rowMinimums    = minimumTracks(element.rowTracks,    rowCells,    margin.Y)
+
+	width  := sumTracks(element.columnMinimums, margin.X)
+	height := sumTracks(element.rowMinimums,    margin.Y)
+	if element.padding {
+		padding := element.entity.Theme().Padding(tomo.PatternBackground, gridCase)
+		width  += padding.Horizontal()
+		height += padding.Vertical()
+	}
+	element.entity.SetMinimumSize(width, height)
+}
+
+// trackCell is the extent and minimum size of a cell along one axis.
+type trackCell struct {
+	start, span, size int
+}
+
+// extendTracks returns a copy of tracks, with implicit tracks added to the end
+// until there are count of them.
+func extendTracks (tracks []Track, count int) []Track {
+	extended := make([]Track, count)
+	copy(extended, tracks)
+	return extended
+}
+
+// minimumTracks calculates the minimum size of each track, given the cells
+// within them.
+func minimumTracks (tracks []Track, cells []trackCell, margin int) []int {
+	minimums := make([]int, len(tracks))
+	for index, track := range tracks {
+		minimums[index] = track.Size
+	}
+
+	// cells that fit in one track only affect that track
+	for _, cell := range cells {
+		if cell.span != 1 { continue }
+		if tracks[cell.start].Size > 0 { continue }
+		if cell.size > minimums[cell.start] {
+			minimums[cell.start] = cell.size
+		}
+	}
+
+	// cells that span multiple tracks grow expanding tracks if they can,
+	// and any sized-to-fit track if they can't
+	for _, cell := range cells {
+		if cell.span == 1 { continue }
+		spanned := minimums[cell.start:cell.start + cell.span]
+		extra := cell.size - sumTracks(spanned, margin)
+		if extra <= 0 { continue }
+
+		targets := []int { }
+		for index := cell.start; index < cell.start + cell.span; index ++ {
+			if tracks[index].Weight > 0 && tracks[index].Size <= 0 {
+				targets = append(targets, index)
+			}
+		}
+		if len(targets) == 0 {
+			for index := cell.start; index < cell.start + cell.span; index ++ {
+				if tracks[index].Size <= 0 {
+					targets = append(targets, index)
+				}
+			}
+		}
+		if len(targets) == 0 { continue }
+
+		for count, index := range targets {
+			share := extra / len(targets)
+			if count < extra % len(targets) { share ++ }
+			minimums[index] += share
+		}
+	}
+
+	return minimums
+}
+
+// distributeTracks calculates the final size of each track, sharing out any
+// free space between expanding tracks.
+func distributeTracks (tracks []Track, minimums []int, available, margin int) []int {
+	sizes := make([]int, len(minimums))
+	copy(sizes, minimums)
+
+	free := available - sumTracks(minimums, margin)
+	totalWeight := 0.0
+	lastExpanding := -1
+	for index, track := range tracks {
+		if track.Weight > 0 && track.Size <= 0 {
+			totalWeight += track.Weight
+			lastExpanding = index
+		}
+	}
+	if free <= 0 || lastExpanding < 0 { return sizes }
+
+	remaining := free
+	for index, track := range tracks {
+		if track.Weight <= 0 || track.Size > 0 { continue }
+		share := int(float64(free) * track.Weight / totalWeight)
+		if index == lastExpanding { share = remaining }
+		sizes[index] += share
+		remaining -= share
+	}
+	return sizes
+}
+
+func trackStarts (sizes []int, start, margin int) []int {
+	starts := make([]int, len(sizes))
+	for index, size := range sizes {
+		starts[index] = start
+		start += size + margin
+	}
+	return starts
+}
+
+func sumTracks (sizes []int, margin int) (total int) {
+	for index, size := range sizes {
+		total += size
+		if index > 0 { total += margin }
+	}
+	return
+}
+
+// alignSpan positions something of the specified size within the span from min
+// to max.
+func alignSpan (align Align, min, max, size int) (int, int) {
+	if size > max - min { size = max - min }
+	switch align {
+	case AlignStart:  return min, min + size
+	case AlignMiddle:
+		min += (max - min - size) / 2
+		return min, min + size
+	case AlignEnd:    return max - size, max
+	default:          return min, max
+	}
+}
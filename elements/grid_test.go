@@ -0,0 +1,128 @@
+package elements
+
+import "testing"
+import "reflect"
+import "tomo"
+
+func TestMinimumTracks (test *testing.T) {
+	cases := []struct {
+		name   string
+		tracks []Track
+		cells  []trackCell
+		margin int
+		result []int
+	} {
+		{
+			"largest cell wins",
+			[]Track { { }, { } },
+			[]trackCell { { 0, 1, 10 }, { 0, 1, 20 }, { 1, 1, 5 } },
+			0, []int { 20, 5 },
+		}, {
+			"fixed size ignores cells",
+			[]Track { { Size: 8 }, { } },
+			[]trackCell { { 0, 1, 30 }, { 1, 1, 5 } },
+			0, []int { 8, 5 },
+		}, {
+			"span grows expanding track",
+			[]Track { { }, { Weight: 1 } },
+			[]trackCell { { 0, 1, 10 }, { 0, 2, 30 } },
+			4, []int { 10, 16 },
+		}, {
+			"span shares between fitted tracks",
+			[]Track { { }, { } },
+			[]trackCell { { 0, 2, 11 } },
+			0, []int { 6, 5 },
+		}, {
+			"span already fits",
+			[]Track { { }, { } },
+			[]trackCell { { 0, 1, 10 }, { 1, 1, 10 }, { 0, 2, 15 } },
+			2, []int { 10, 10 },
+		},
+	}
+
+	for _, current := range cases {
+		result := minimumTracks(current.tracks, current.cells, current.margin)
+		if !reflect.DeepEqual(result, current.result) {
+			test.Errorf (
+				"%s: got %v, expected %v",
+				current.name, result, current.result)
+		}
+	}
+}
+
+func TestDistributeTracks (test *testing.T) {
+	cases := []struct {
+		name      string
+		tracks    []Track
+		minimums  []int
+		available int
+		margin    int
+		result    []int
+	} {
+		{
+			"no expanding tracks",
+			[]Track { { }, { } },
+			[]int { 10, 20 }, 100, 0, []int { 10, 20 },
+		}, {
+			"weights share free space",
+			[]Track { { Weight: 1 }, { }, { Weight: 3 } },
+			[]int { 0, 10, 0 }, 54, 2, []int { 10, 10, 30 },
+		}, {
+			"remainder goes to last expanding track",
+			[]Track { { Weight: 1 }, { Weight: 1 }, { Weight: 1 } },
+			[]int { 0, 0, 0 }, 10, 0, []int { 3, 3, 4 },
+		}, {
+			"fixed size tracks don't expand",
+			[]Track { { Size: 5, Weight: 1 }, { Weight: 1 } },
+			[]int { 5, 0 }, 20, 0, []int { 5, 15 },
+		}, {
+			"not enough space",
+			[]Track { { Weight: 1 }, { } },
+			[]int { 10, 10 }, 15, 0, []int { 10, 10 },
+		},
+	}
+
+	for _, current := range cases {
+		result := distributeTracks (
+			current.tracks, current.minimums,
+			current.available, current.margin)
+		if !reflect.DeepEqual(result, current.result) {
+			test.Errorf (
+				"%s: got %v, expected %v",
+				current.name, result, current.result)
+		}
+	}
+}
+
+func TestGridImplicitTracks (test *testing.T) {
+	var near, far tomo.Element = &Label { }, &Label { }
+	element := &Grid {
+		columns: []Track { { Weight: 1 } },
+		cells: map[tomo.Element] gridCell {
+			near: { column: 0, row: 0, columnSpan: 1, rowSpan: 1 },
+			far:  { column: 2, row: 1, columnSpan: 2, rowSpan: 3 },
+		},
+	}
+
+	element.updateTracks()
+	if len(element.columnTracks) != 4 || len(element.rowTracks) != 4 {
+		test.Fatalf (
+			"got %d columns and %d rows, expected 4 and 4",
+			len(element.columnTracks), len(element.rowTracks))
+	}
+	if element.columnTracks[0].Weight != 1 {
+		test.Error("set column was not kept")
+	}
+
+	delete(element.cells, far)
+	element.updateTracks()
+	if len(element.columnTracks) != 1 || len(element.rowTracks) != 1 {
+		test.Errorf (
+			"got %d columns and %d rows after removing cell, " +
+			"expected 1 and 1",
+			len(element.columnTracks), len(element.rowTracks))
+	}
+	if len(element.columns) != 1 || len(element.rows) != 0 {
+		test.Error("implicit tracks leaked into set tracks")
+	}
+}
@@ -5,19 +5,24 @@ import "golang.org/x/image/math/fixed"
 import "tomo"
 import "tomo/data"
 import "tomo/input"
+import "tomo/ability"
+import "tomo/menu"
 import "art"
 import "tomo/textdraw"
 
 var labelCase = tomo.C("tomo", "label")
 
-// Label is a simple text box.
+// Label is a simple text box. It can describe another element, in which case
+// clicking on it, or pressing its mnemonic along with Alt, focuses that element.
 type Label struct {
 	entity tomo.Entity
+	c      tomo.Case
 	
 	align  textdraw.Align
 	wrap   bool
-	text   string
+	label  labelText
 	drawer textdraw.Drawer
+	target tomo.Element
 
 	forcedColumns int
 	forcedRows    int
@@ -26,11 +31,11 @@ type Label struct {
 
 // NewLabel creates a new label.
 func NewLabel (text string) (element *Label) {
-	element = &Label { }
+	element = &Label { c: labelCase }
 	element.entity = tomo.GetBackend().NewEntity(element)
 	element.drawer.SetFace (element.entity.Theme().FontFace (
 		tomo.FontStyleRegular,
-		tomo.FontSizeNormal, labelCase))
+		tomo.FontSizeNormal, element.c))
 	element.SetText(text)
 	return
 }
@@ -61,15 +66,17 @@ func (element *Label) Draw (destination art.Canvas) {
 	textBounds := element.drawer.LayoutBounds()
 	foreground := element.entity.Theme().Color (
 		tomo.ColorForeground,
-		tomo.State { }, labelCase)
-	element.drawer.Draw(destination, foreground, bounds.Min.Sub(textBounds.Min))
+		tomo.State { Disabled: !element.targetEnabled() }, element.c)
+	offset := bounds.Min.Sub(textBounds.Min)
+	element.drawer.Draw(destination, foreground, offset)
+	element.label.underline(element.drawer, destination, foreground, offset)
 }
 
 // Copy copies the label's textto the clipboard.
 func (element *Label) Copy () {
 	window := element.entity.Window()
 	if window != nil {
-		window.Copy(data.Bytes(data.MimePlain, []byte(element.text)))
+		window.Copy(data.Bytes(data.MimePlain, []byte(element.label.String())))
 	}
 }
 
@@ -96,14 +103,61 @@ func (element *Label) FlexibleHeightFor (width int) (height int) {
 
 // SetText sets the label's text.
 func (element *Label) SetText (text string) {
-	if element.text == text { return }
+	element.setText(text, false)
+}
+
+// SetTextWithMnemonic is like SetText, but an underscore marks the character
+// after it as the mnemonic, which can be pressed along with Alt to focus the
+// label's target. Use two underscores to display a literal underscore.
+func (element *Label) SetTextWithMnemonic (text string) {
+	element.setText(text, true)
+}
 
-	element.text = text
-	element.drawer.SetText([]rune(text))
+func (element *Label) setText (text string, parse bool) {
+	display, changed := element.label.set(text, parse)
+	if !changed { return }
+	element.drawer.SetText(display)
 	element.updateMinimumSize()
 	element.entity.Invalidate()
 }
 
+// SetTarget sets the element that the label describes, which is focused when
+// the label's mnemonic is pressed.
+func (element *Label) SetTarget (target tomo.Element) {
+	element.target = target
+}
+
+// Target returns the element that the label describes.
+func (element *Label) Target () tomo.Element {
+	return element.target
+}
+
+// Mnemonic returns the label's mnemonic. A label without a target has no
+// mnemonic, since pressing it would do nothing.
+func (element *Label) Mnemonic () rune {
+	if element.target == nil { return 0 }
+	return element.label.mnemonic
+}
+
+func (element *Label) HandleMnemonic () {
+	element.focusTarget()
+}
+
+func (element *Label) HandleMouseDown (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) {
+	if button != input.ButtonLeft || !element.targetEnabled() { return }
+	element.focusTarget()
+}
+
+func (element *Label) HandleMouseUp (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) { }
+
 // SetWrap sets wether or not the label's text wraps. If the text is set to
 // wrap, the element will have a minimum size of a single character and
 // automatically wrap its text. If the text is set to not wrap, the element will
@@ -131,47 +185,37 @@ func (element *Label) SetAlign (align textdraw.Align) {
 func (element *Label) HandleThemeChange () {
 	element.drawer.SetFace (element.entity.Theme().FontFace (
 		tomo.FontStyleRegular,
-		tomo.FontSizeNormal, labelCase))
+		tomo.FontSizeNormal, element.c))
 	element.updateMinimumSize()
 	element.entity.Invalidate()
 }
 
-func (element *Label) HandleMouseDown  (
-	position image.Point,
-	button input.Button,
-	modifiers input.Modifiers,
-) {
-	if button == input.ButtonRight {
-		element.contextMenu(position)
-	}
+// setCase changes the case the label uses to ask the theme how to style it.
+func (element *Label) setCase (c tomo.Case) {
+	element.c = c
+	element.HandleThemeChange()
 }
 
-func (element *Label) HandleMouseUp  (
-	position image.Point,
-	button input.Button,
-	modifiers input.Modifiers,
-) { }
-
-func (element *Label) contextMenu (position image.Point) {
-	window := element.entity.Window()
-	menu, err := window.NewMenu(image.Rectangle { position, position })
-	if err != nil { return }
+func (element *Label) focusTarget () {
+	if target, ok := element.target.(interface { Focus () }); ok {
+		target.Focus()
+	}
+}
 
-	closeAnd := func (callback func ()) func () {
-		return func () { callback(); menu.Close() }
+// targetEnabled returns whether the label's target is enabled. Labels without
+// an Enableable target are always considered enabled.
+func (element *Label) targetEnabled () bool {
+	if target, ok := element.target.(ability.Enableable); ok {
+		return target.Enabled()
 	}
-	
-	copyButton := NewButton("Copy")
-	copyButton.ShowText(false)
-	copyButton.SetIcon(tomo.IconCopy)
-	copyButton.OnClick(closeAnd(element.Copy))
+	return true
+}
 
-	menu.Adopt (NewHBox (
-		SpaceNone,
-		copyButton,
-	))
-	copyButton.Focus()
-	menu.Show()
+// ContextMenu returns the items to display in the label's context menu.
+func (element *Label) ContextMenu (position image.Point) menu.Menu {
+	copyItem := menu.Action("_Copy", element.Copy)
+	copyItem.Icon = tomo.IconCopy
+	return menu.Menu { copyItem }
 }
 
 func (element *Label) updateMinimumSize () {
@@ -180,7 +224,7 @@ func (element *Label) updateMinimumSize () {
 	if element.wrap {
 		em := element.drawer.Em().Round()
 		if em < 1 {
-			em = element.entity.Theme().Padding(tomo.PatternBackground, labelCase)[0]
+			em = element.entity.Theme().Padding(tomo.PatternBackground, element.c)[0]
 		}
 		width, height = em, element.drawer.LineHeight().Round()
 		element.entity.NotifyFlexibleHeightChange()
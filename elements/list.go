@@ -13,16 +13,16 @@ type list struct {
 
 	c tomo.Case
 
+	items         childItems
+	selector      selector
 	enabled       bool
 	scroll        image.Point
 	contentBounds image.Rectangle
-	selected      int
 	
 	forcedMinimumWidth  int
 	forcedMinimumHeight int
 
 	onClick func ()
-	onSelectionChange func ()
 	onScrollBoundsChange func ()
 }
 
@@ -55,8 +55,9 @@ func NewFlowList (children ...tomo.Element) (element *FlowList) {
 }
 
 func (element *list) init (children ...tomo.Element) {
-	element.selected = -1
-	element.enabled  = true
+	element.items.entity   = element.entity
+	element.selector.items = &element.items
+	element.enabled = true
 	element.container.init()
 	element.Adopt(children...)
 }
@@ -164,25 +165,60 @@ func (element *FlowList) Layout () {
 	}
 }
 
+// Disown removes one or more elements from the list. Elements that are removed
+// are no longer part of the selection.
+func (element *list) Disown (children ...tomo.Element) {
+	element.container.Disown(children...)
+	element.items.forget()
+}
+
+// DisownAll removes all elements from the list.
+func (element *list) DisownAll () {
+	element.container.DisownAll()
+	element.items.forget()
+}
+
+// Selected returns the selected element. If more than one element is selected,
+// the one that was selected most recently is returned.
 func (element *list) Selected () ability.Selectable {
-	if element.selected == -1 { return nil }
-	child, ok := element.entity.Child(element.selected).(ability.Selectable)
-	if !ok { return nil }
-	return child
+	if child, ok := element.items.cursor.(ability.Selectable); ok {
+		if child.Entity().Selected() { return child }
+	}
+	selection := element.items.selection()
+	if len(selection) == 0 { return nil }
+	return selection[0]
 }
 
+// Selection returns all selected elements, in order.
+func (element *list) Selection () []ability.Selectable {
+	return element.items.selection()
+}
+
+// Select selects an element, deselecting all others.
 func (element *list) Select (child ability.Selectable) {
 	index := element.entity.IndexOf(child)
-	if element.selected == index { return }
-	element.selectNone()
-	element.selected = index
-	element.entity.SelectChild(index, true)
-	if element.onSelectionChange != nil {
-		element.onSelectionChange()
-	}
+	if index < 0 { return }
+	element.selector.pick(index, input.Modifiers { }, false)
 	element.scrollToSelected()
 }
 
+// SelectAll selects every element in the list, if more than one element can be
+// selected.
+func (element *list) SelectAll () {
+	element.selector.selectAll()
+}
+
+// SelectionMode returns how many elements can be selected at once.
+func (element *list) SelectionMode () SelectionMode {
+	return element.selector.mode
+}
+
+// SetSelectionMode sets how many elements can be selected at once. By default,
+// only one element can be selected.
+func (element *list) SetSelectionMode (mode SelectionMode) {
+	element.selector.setMode(mode)
+}
+
 func (element *list) Enabled () bool {
 	return element.enabled
 }
@@ -208,7 +244,9 @@ func (element *list) HandleMouseDown (
 ) {
 	if !element.enabled { return }
 	element.Focus()
-	element.selectNone()
+	if !modifiers.Control && !modifiers.Shift {
+		element.selector.selectNone()
+	}
 }
 
 func (element *list) HandleMouseUp (
@@ -225,8 +263,9 @@ func (element *list) HandleChildMouseDown (
 ) {
 	if !element.enabled { return }
 	element.Focus()
-	if child, ok := child.(ability.Selectable); ok {
-		element.Select(child)
+	if _, ok := child.(ability.Selectable); ok {
+		element.selector.pick(element.entity.IndexOf(child), modifiers, false)
+		element.scrollToSelected()
 	}
 }
 
@@ -250,24 +289,24 @@ func (element *list) HandleChildFlexibleHeightChange (child ability.Flexible) {
 
 func (element *list) HandleKeyDown (key input.Key, modifiers input.Modifiers) {
 	if !element.Enabled() { return }
-	index := -1
+	cursor := element.items.cursorIndex()
+	index  := -1
 	switch key {
 	case input.KeyUp, input.KeyLeft:
-		index = element.selected - 1
+		index = cursor - 1
 	case input.KeyDown, input.KeyRight:
-		index = element.selected + 1
+		index = cursor + 1
+	case ' ':
+		index = cursor
+	case 'a':
+		if modifiers.Control { element.selector.selectAll() }
 	case input.KeyEnter:
 		if element.onClick != nil {
 			element.onClick()
 		}
 	}
 	if index >= 0 && index < element.entity.CountChildren() {
-		element.selectNone()
-		element.selected = index
-		element.entity.SelectChild(index, true)
-	if element.onSelectionChange != nil {
-		element.onSelectionChange()
-	}
+		element.selector.pick(index, modifiers, key != ' ')
 		element.scrollToSelected()
 	}
 }
@@ -344,8 +383,10 @@ func (element *list) OnClick (callback func ()) {
 	element.onClick = callback
 }
 
+// OnSelectionChange sets a function to be called when elements are selected or
+// deselected.
 func (element *list) OnSelectionChange (callback func ()) {
-	element.onSelectionChange = callback
+	element.selector.onSelectionChange = callback
 }
 
 // ScrollAxes returns the supported axes for scrolling.
@@ -353,18 +394,10 @@ func (element *list) ScrollAxes () (horizontal, vertical bool) {
 	return false, true
 }
 
-func (element *list) selectNone () {
-	if element.selected >= 0 {
-		element.entity.SelectChild(element.selected, false)
-	if element.onSelectionChange != nil {
-		element.onSelectionChange()
-	}
-	}
-}
-
 func (element *list) scrollToSelected () {
-	if element.selected < 0 { return }
-	target := element.entity.Child(element.selected).Entity().Bounds()
+	cursor := element.items.cursorIndex()
+	if cursor < 0 { return }
+	target := element.entity.Child(cursor).Entity().Bounds()
 	padding := element.entity.Theme().Padding(tomo.PatternSunken, element.c)
 	bounds  := padding.Apply(element.entity.Bounds())
 	if target.Min.Y < bounds.Min.Y {
@@ -0,0 +1,503 @@
+package elements
+
+import "image"
+import "unicode"
+import "tomo"
+import "tomo/menu"
+import "tomo/input"
+import "art"
+import "tomo/textdraw"
+
+var menuCase      = tomo.C("tomo", "menu")
+var menuItemCase  = tomo.C("tomo", "menu", "item")
+var menuCheckCase = tomo.C("tomo", "menu", "check")
+var menuRadioCase = tomo.C("tomo", "menu", "radio")
+
+// context menus are opened by the backend, which can't depend on this package
+func init () {
+	menu.SetPresenter(PopupMenu)
+}
+
+// PopupMenu opens a popup menu displaying the given items, with its top left
+// corner at the specified position relative to the window. Submenus are opened
+// when the user hovers over them or presses the right arrow key. The entire
+// chain of menus is closed when an item is activated, or when the user clicks
+// outside of them.
+func PopupMenu (window tomo.Window, position image.Point, items menu.Menu) error {
+	_, err := openMenuPane(window, position, items, nil)
+	return err
+}
+
+type menuRow struct {
+	drawer            textdraw.Drawer
+	acceleratorDrawer textdraw.Drawer
+	mnemonic          rune
+	mnemonicIndex     int
+}
+
+// menuPane is the element that renders the contents of a popup menu. Each menu
+// in a chain of submenus has its own window and pane.
+type menuPane struct {
+	entity tomo.Entity
+	items  menu.Menu
+	rows   []menuRow
+
+	selected int
+	pressed  bool
+	closed   bool
+
+	window tomo.MenuWindow
+	origin image.Point
+	parent *menuPane
+	child  *menuPane
+
+	// these are only used by root panes, so that a menu bar can take over
+	// when the user navigates or hovers outside of the menu.
+	onNavigate func (direction int)
+	onHover    func (position image.Point)
+	onClose    func ()
+}
+
+func openMenuPane (
+	window   tomo.Window,
+	position image.Point,
+	items    menu.Menu,
+	parent   *menuPane,
+) (
+	pane *menuPane,
+	err  error,
+) {
+	menuWindow, err := window.NewMenu(image.Rectangle { position, position })
+	if err != nil { return nil, err }
+
+	pane = &menuPane {
+		items:    items,
+		rows:     make([]menuRow, len(items)),
+		selected: -1,
+		window:   menuWindow,
+		origin:   position,
+		parent:   parent,
+	}
+	pane.entity = tomo.GetBackend().NewEntity(pane)
+	pane.updateFaces()
+	for index, item := range items {
+		row := &pane.rows[index]
+		var display []rune
+		display, row.mnemonic, row.mnemonicIndex =
+			textdraw.ParseMnemonic(item.Text)
+		row.drawer.SetText(display)
+		if item.Accelerator.Key != input.KeyNone {
+			row.acceleratorDrawer.SetText([]rune(item.Accelerator.String()))
+		}
+	}
+	pane.updateMinimumSize()
+
+	menuWindow.OnClose(pane.handleClose)
+	menuWindow.Adopt(pane)
+	pane.entity.Focus()
+	menuWindow.Show()
+	return pane, nil
+}
+
+func (element *menuPane) Entity () tomo.Entity {
+	return element.entity
+}
+
+func (element *menuPane) Draw (destination art.Canvas) {
+	bounds := element.entity.Bounds()
+	element.entity.Theme().Pattern(tomo.PatternRaised, tomo.State { }, menuCase).
+		Draw(destination, bounds)
+
+	columns := element.columns()
+	for index := range element.items {
+		element.drawRow(destination, index, columns)
+	}
+}
+
+func (element *menuPane) drawRow (destination art.Canvas, index int, columns menuColumns) {
+	item   := element.items[index]
+	row    := &element.rows[index]
+	bounds := element.rowBounds(index)
+	theme  := element.entity.Theme()
+
+	if item.Kind == menu.KindSeparator {
+		padding := theme.Padding(tomo.PatternLine, menuCase)
+		middle  := bounds.Min.Y + bounds.Dy() / 2
+		line    := image.Rect (
+			bounds.Min.X, middle - padding[0],
+			bounds.Max.X, middle + padding[2])
+		theme.Pattern(tomo.PatternLine, tomo.State { }, menuCase).
+			Draw(destination, line)
+		return
+	}
+
+	state := tomo.State {
+		Disabled: item.Disabled,
+		On:       index == element.selected,
+		Pressed:  index == element.selected && element.pressed,
+	}
+	if state.On {
+		theme.Pattern(tomo.PatternTableCell, state, menuItemCase).
+			Draw(destination, bounds)
+	}
+
+	foreground := theme.Color(tomo.ColorForeground, state, menuItemCase)
+	padding    := theme.Padding(tomo.PatternTableCell, menuItemCase)
+	inner      := padding.Apply(bounds)
+	x          := inner.Min.X
+
+	// check box, radio button, or icon
+	if columns.slot > 0 {
+		slot := image.Rect(x, inner.Min.Y, x + columns.slot, inner.Max.Y)
+		switch item.Kind {
+		case menu.KindCheck, menu.KindRadio:
+			c := menuCheckCase
+			if item.Kind == menu.KindRadio { c = menuRadioCase }
+			size := slot.Dy()
+			if slot.Dx() < size { size = slot.Dx() }
+			box := image.Rect(0, 0, size, size).Add(slot.Min)
+			theme.Pattern (tomo.PatternButton, tomo.State {
+				Disabled: item.Disabled,
+				On:       item.Checked,
+			}, c).Draw(destination, box)
+		default:
+			if item.Icon != tomo.IconNone {
+				icon := theme.Icon(item.Icon, tomo.IconSizeSmall, menuItemCase)
+				if icon != nil {
+					iconBounds := icon.Bounds()
+					icon.Draw(destination, foreground, image.Pt (
+						slot.Min.X,
+						slot.Min.Y + (slot.Dy() - iconBounds.Dy()) / 2))
+				}
+			}
+		}
+		x += columns.slot + columns.margin
+	}
+
+	// label text
+	textBounds := row.drawer.LayoutBounds()
+	offset := image.Pt (
+		x,
+		inner.Min.Y + (inner.Dy() - textBounds.Dy()) / 2).Sub(textBounds.Min)
+	row.drawer.Draw(destination, foreground, offset)
+	if row.mnemonic != 0 {
+		row.drawer.DrawUnderline (
+			destination, foreground, offset,
+			row.mnemonicIndex)
+	}
+
+	// submenu arrow
+	right := inner.Max.X
+	if columns.arrow > 0 {
+		if item.Submenu != nil {
+			icon := theme.Icon(tomo.IconForward, tomo.IconSizeSmall, menuItemCase)
+			if icon != nil {
+				iconBounds := icon.Bounds()
+				icon.Draw(destination, foreground, image.Pt (
+					right - iconBounds.Dx(),
+					inner.Min.Y + (inner.Dy() - iconBounds.Dy()) / 2))
+			}
+		}
+		right -= columns.arrow + columns.margin
+	}
+
+	// accelerator, right aligned
+	if item.Accelerator.Key != input.KeyNone {
+		textBounds := row.acceleratorDrawer.LayoutBounds()
+		offset := image.Pt (
+			right - textBounds.Dx(),
+			inner.Min.Y + (inner.Dy() - textBounds.Dy()) / 2).Sub(textBounds.Min)
+		accelColor := theme.Color (
+			tomo.ColorForeground,
+			tomo.State { Disabled: true }, menuItemCase)
+		row.acceleratorDrawer.Draw(destination, accelColor, offset)
+	}
+}
+
+func (element *menuPane) Enabled () bool { return true }
+
+func (element *menuPane) SetEnabled (bool) { }
+
+func (element *menuPane) HandleFocusChange () { }
+
+func (element *menuPane) HandleThemeChange () {
+	element.updateFaces()
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+}
+
+func (element *menuPane) HandleMouseDown (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) {
+	if button != input.ButtonLeft { return }
+	index := element.rowAt(position)
+	if index < 0 { return }
+	if index != element.selected { element.selectRow(index, false) }
+	element.pressed = true
+	element.entity.Invalidate()
+}
+
+func (element *menuPane) HandleMouseUp (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) {
+	if button != input.ButtonLeft || !element.pressed { return }
+	element.pressed = false
+	element.entity.Invalidate()
+	index := element.rowAt(position)
+	if index >= 0 && index == element.selected {
+		element.activate(index, false)
+	}
+}
+
+func (element *menuPane) HandleMotion (position image.Point) {
+	if position.In(element.entity.Bounds()) {
+		index := element.rowAt(position)
+		if index >= 0 && index != element.selected {
+			element.selectRow(index, true)
+		}
+		return
+	}
+
+	// the pointer is grabbed by this menu, so pass the motion on to
+	// whatever is underneath it
+	position = position.Add(element.origin)
+	if element.parent != nil {
+		element.parent.HandleMotion(position)
+	} else if element.onHover != nil {
+		element.onHover(position)
+	}
+}
+
+func (element *menuPane) HandleKeyDown (key input.Key, modifiers input.Modifiers) {
+	switch key {
+	case input.KeyUp:   element.step(-1)
+	case input.KeyDown: element.step(1)
+	case input.KeyHome:
+		element.selected = -1
+		element.step(1)
+	case input.KeyEnd:
+		element.selected = len(element.items)
+		element.step(-1)
+	case input.KeyRight:
+		if element.selected >= 0 && element.items[element.selected].Submenu != nil {
+			element.openSubmenu(element.selected, true)
+		} else if root := element.root(); root.onNavigate != nil {
+			root.onNavigate(1)
+		}
+	case input.KeyLeft:
+		if element.parent != nil {
+			element.close()
+		} else if element.onNavigate != nil {
+			element.onNavigate(-1)
+		}
+	case input.KeyEnter, ' ':
+		if element.selected >= 0 {
+			element.activate(element.selected, true)
+		}
+	default:
+		if key.Printable() && !modifiers.Control && !modifiers.Alt {
+			element.activateMnemonic(rune(key))
+		}
+	}
+}
+
+func (element *menuPane) HandleKeyUp (key input.Key, modifiers input.Modifiers) { }
+
+func (element *menuPane) handleClose () {
+	element.closed = true
+	if element.child != nil { element.child.close() }
+	if element.parent != nil && element.parent.child == element {
+		element.parent.child = nil
+	}
+	if element.onClose != nil { element.onClose() }
+}
+
+// close closes this menu and all of its submenus.
+func (element *menuPane) close () {
+	if element.closed { return }
+	element.window.Close()
+}
+
+func (element *menuPane) root () *menuPane {
+	for element.parent != nil { element = element.parent }
+	return element
+}
+
+// selectRow highlights a row. If it has a submenu and hover is true, the
+// submenu is opened.
+func (element *menuPane) selectRow (index int, hover bool) {
+	if element.child != nil { element.child.close() }
+	element.selected = index
+	element.entity.Invalidate()
+	item := element.items[index]
+	if hover && item.Activatable() && item.Submenu != nil {
+		element.openSubmenu(index, false)
+	}
+}
+
+func (element *menuPane) step (direction int) {
+	count := len(element.items)
+	if count == 0 { return }
+	index := element.selected
+	for range element.items {
+		index += direction
+		if index < 0      { index = count - 1 }
+		if index >= count { index = 0 }
+		if element.items[index].Activatable() {
+			element.selectRow(index, false)
+			return
+		}
+	}
+}
+
+func (element *menuPane) activate (index int, keyboard bool) {
+	item := element.items[index]
+	if !item.Activatable() { return }
+	if item.Submenu != nil {
+		element.openSubmenu(index, keyboard)
+		return
+	}
+
+	// close everything first, so that the callback is free to open new
+	// windows or menus
+	element.root().close()
+	element.items.Activate(index)
+}
+
+func (element *menuPane) activateMnemonic (char rune) {
+	char = unicode.ToLower(char)
+	for index, row := range element.rows {
+		if row.mnemonic != 0 && unicode.ToLower(row.mnemonic) == char {
+			element.selectRow(index, false)
+			element.activate(index, true)
+			return
+		}
+	}
+}
+
+func (element *menuPane) openSubmenu (index int, keyboard bool) {
+	if element.child != nil {
+		if element.selected == index {
+			if keyboard { element.child.step(1) }
+			return
+		}
+		element.child.close()
+	}
+	element.selected = index
+	element.entity.Invalidate()
+
+	rowBounds := element.rowBounds(index)
+	child, err := openMenuPane (
+		element.window,
+		image.Pt(element.entity.Bounds().Max.X, rowBounds.Min.Y),
+		element.items[index].Submenu,
+		element)
+	if err != nil { return }
+	element.child = child
+	if keyboard { child.step(1) }
+}
+
+type menuColumns struct {
+	slot, text, accelerator, arrow, margin int
+}
+
+func (element *menuPane) columns () (columns menuColumns) {
+	theme := element.entity.Theme()
+	columns.margin = theme.Margin(tomo.PatternTableCell, menuItemCase).X
+	for index, item := range element.items {
+		row := &element.rows[index]
+		if item.Kind == menu.KindSeparator { continue }
+		switch {
+		case item.Kind == menu.KindCheck || item.Kind == menu.KindRadio:
+			height := row.drawer.LineHeight().Round()
+			if height > columns.slot { columns.slot = height }
+		case item.Icon != tomo.IconNone:
+			icon := theme.Icon(item.Icon, tomo.IconSizeSmall, menuItemCase)
+			if icon != nil && icon.Bounds().Dx() > columns.slot {
+				columns.slot = icon.Bounds().Dx()
+			}
+		}
+		if width := row.drawer.LayoutBounds().Dx(); width > columns.text {
+			columns.text = width
+		}
+		if item.Accelerator.Key != input.KeyNone {
+			width := row.acceleratorDrawer.LayoutBounds().Dx()
+			if width > columns.accelerator { columns.accelerator = width }
+		}
+		if item.Submenu != nil {
+			icon := theme.Icon(tomo.IconForward, tomo.IconSizeSmall, menuItemCase)
+			if icon != nil { columns.arrow = icon.Bounds().Dx() }
+		}
+	}
+	return
+}
+
+func (element *menuPane) rowHeight (index int) int {
+	theme := element.entity.Theme()
+	if element.items[index].Kind == menu.KindSeparator {
+		return theme.Margin(tomo.PatternTableCell, menuItemCase).Y
+	}
+	padding := theme.Padding(tomo.PatternTableCell, menuItemCase)
+	height  := element.rows[index].drawer.LineHeight().Round()
+	if icon := theme.Icon(tomo.IconForward, tomo.IconSizeSmall, menuItemCase); icon != nil {
+		if icon.Bounds().Dy() > height { height = icon.Bounds().Dy() }
+	}
+	return height + padding.Vertical()
+}
+
+func (element *menuPane) rowBounds (index int) image.Rectangle {
+	padding := element.entity.Theme().Padding(tomo.PatternRaised, menuCase)
+	inner   := padding.Apply(element.entity.Bounds())
+	y := inner.Min.Y
+	for current := 0; current < index; current ++ {
+		y += element.rowHeight(current)
+	}
+	return image.Rect(inner.Min.X, y, inner.Max.X, y + element.rowHeight(index))
+}
+
+func (element *menuPane) rowAt (position image.Point) int {
+	for index := range element.items {
+		if position.In(element.rowBounds(index)) {
+			if !element.items[index].Activatable() { return -1 }
+			return index
+		}
+	}
+	return -1
+}
+
+func (element *menuPane) updateFaces () {
+	face := element.entity.Theme().FontFace (
+		tomo.FontStyleRegular,
+		tomo.FontSizeNormal,
+		menuItemCase)
+	for index := range element.rows {
+		element.rows[index].drawer.SetFace(face)
+		element.rows[index].acceleratorDrawer.SetFace(face)
+	}
+}
+
+func (element *menuPane) updateMinimumSize () {
+	theme   := element.entity.Theme()
+	padding := theme.Padding(tomo.PatternRaised, menuCase)
+	rowPadding := theme.Padding(tomo.PatternTableCell, menuItemCase)
+
+	columns := element.columns()
+	width   := columns.text
+	if columns.slot        > 0 { width += columns.slot        + columns.margin }
+	if columns.accelerator > 0 { width += columns.accelerator + columns.margin }
+	if columns.arrow       > 0 { width += columns.arrow       + columns.margin }
+	width += rowPadding.Horizontal()
+
+	height := 0
+	for index := range element.items {
+		height += element.rowHeight(index)
+	}
+
+	element.entity.SetMinimumSize (
+		width  + padding.Horizontal(),
+		height + padding.Vertical())
+}
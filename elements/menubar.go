@@ -0,0 +1,316 @@
+package elements
+
+import "image"
+import "unicode"
+import "tomo"
+import "tomo/menu"
+import "tomo/input"
+import "art"
+import "tomo/textdraw"
+
+var menuBarCase     = tomo.C("tomo", "menuBar")
+var menuBarItemCase = tomo.C("tomo", "menuBar", "item")
+
+// MenuBar is a horizontal strip of menus, typically placed at the top of a
+// window. Each of its items opens a popup menu containing the item's submenu.
+// Items without a submenu are activated directly.
+type MenuBar struct {
+	entity tomo.Entity
+	items  menu.Menu
+	rows   []menuRow
+
+	enabled  bool
+	selected int
+	open     *menuPane
+}
+
+// NewMenuBar creates a new menu bar containing the specified items.
+func NewMenuBar (items ...*menu.Item) (element *MenuBar) {
+	element = &MenuBar {
+		items:    items,
+		rows:     make([]menuRow, len(items)),
+		enabled:  true,
+		selected: -1,
+	}
+	element.entity = tomo.GetBackend().NewEntity(element)
+	element.updateFaces()
+	for index, item := range items {
+		row := &element.rows[index]
+		var display []rune
+		display, row.mnemonic, row.mnemonicIndex =
+			textdraw.ParseMnemonic(item.Text)
+		row.drawer.SetText(display)
+	}
+	element.updateMinimumSize()
+	return
+}
+
+// Entity returns this element's entity.
+func (element *MenuBar) Entity () tomo.Entity {
+	return element.entity
+}
+
+// Draw causes the element to draw to the specified destination canvas.
+func (element *MenuBar) Draw (destination art.Canvas) {
+	bounds := element.entity.Bounds()
+	theme  := element.entity.Theme()
+	theme.Pattern(tomo.PatternRaised, tomo.State { }, menuBarCase).
+		Draw(destination, bounds)
+
+	padding := theme.Padding(tomo.PatternTableCell, menuBarItemCase)
+	for index, item := range element.items {
+		row := &element.rows[index]
+		titleBounds := element.titleBounds(index)
+		state := tomo.State {
+			Disabled: item.Disabled || !element.enabled,
+			Focused:  element.entity.Focused(),
+			On:       index == element.selected,
+		}
+		highlight := state.On && (element.open != nil || state.Focused)
+		if highlight {
+			theme.Pattern(tomo.PatternTableCell, state, menuBarItemCase).
+				Draw(destination, titleBounds)
+		}
+
+		foreground := theme.Color(tomo.ColorForeground, state, menuBarItemCase)
+		inner      := padding.Apply(titleBounds)
+		textBounds := row.drawer.LayoutBounds()
+		offset := image.Pt (
+			inner.Min.X,
+			inner.Min.Y + (inner.Dy() - textBounds.Dy()) / 2).Sub(textBounds.Min)
+		row.drawer.Draw(destination, foreground, offset)
+		if row.mnemonic != 0 {
+			row.drawer.DrawUnderline (
+				destination, foreground, offset,
+				row.mnemonicIndex)
+		}
+	}
+}
+
+// Menu returns the items in the menu bar.
+func (element *MenuBar) Menu () menu.Menu {
+	return element.items
+}
+
+// RegisterAccelerators adds the accelerators of every item in the menu bar to
+// the accelerator table of the specified window, so that they work while the
+// menus are closed. It also binds Alt and the mnemonic of each menu to opening
+// that menu, and F10 to opening the first one. If any of them conflict with
+// accelerators already in the table, an error is returned and the table is left
+// unchanged.
+func (element *MenuBar) RegisterAccelerators (window tomo.Window) error {
+	table := window.Accelerators()
+	err := menu.RegisterAccelerators(table, element.items)
+	if err != nil { return err }
+
+	added := []tomo.Accelerator { }
+	rollback := func (err error) error {
+		menu.UnregisterAccelerators(table, element.items)
+		for _, accelerator := range added {
+			table.Remove(accelerator)
+		}
+		return err
+	}
+
+	for index, row := range element.rows {
+		if row.mnemonic == 0 { continue }
+		index := index
+		accelerator := tomo.A (
+			input.Key(unicode.ToLower(row.mnemonic)),
+			input.Modifiers { Alt: true })
+		err := table.Add(accelerator, func () { element.openMenu(index, true) })
+		if err != nil { return rollback(err) }
+		added = append(added, accelerator)
+	}
+
+	err = table.Add(tomo.A(input.KeyF10, input.Modifiers { }), func () {
+		element.selected = -1
+		element.navigate(1)
+	})
+	if err != nil { return rollback(err) }
+	return nil
+}
+
+// Focus gives this element input focus.
+func (element *MenuBar) Focus () {
+	if !element.entity.Focused() { element.entity.Focus() }
+}
+
+// Enabled returns whether this menu bar is enabled or not.
+func (element *MenuBar) Enabled () bool {
+	return element.enabled
+}
+
+// SetEnabled sets whether this menu bar can be interacted with or not.
+func (element *MenuBar) SetEnabled (enabled bool) {
+	if element.enabled == enabled { return }
+	element.enabled = enabled
+	if !enabled && element.open != nil { element.open.close() }
+	element.entity.Invalidate()
+}
+
+func (element *MenuBar) HandleThemeChange () {
+	element.updateFaces()
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+}
+
+func (element *MenuBar) HandleFocusChange () {
+	if element.entity.Focused() && element.selected < 0 {
+		element.selected = element.next(-1, 1)
+	}
+	element.entity.Invalidate()
+}
+
+func (element *MenuBar) HandleMouseDown (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) {
+	if !element.enabled || button != input.ButtonLeft { return }
+	index := element.titleAt(position)
+	if index < 0 { return }
+	element.openMenu(index, false)
+}
+
+func (element *MenuBar) HandleMouseUp (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) { }
+
+func (element *MenuBar) HandleKeyDown (key input.Key, modifiers input.Modifiers) {
+	if !element.enabled { return }
+	switch key {
+	case input.KeyLeft:
+		element.selected = element.next(element.selected, -1)
+		element.entity.Invalidate()
+	case input.KeyRight:
+		element.selected = element.next(element.selected, 1)
+		element.entity.Invalidate()
+	case input.KeyDown, input.KeyEnter, ' ':
+		if element.selected >= 0 {
+			element.openMenu(element.selected, true)
+		}
+	}
+}
+
+func (element *MenuBar) HandleKeyUp (key input.Key, modifiers input.Modifiers) { }
+
+func (element *MenuBar) openMenu (index int, keyboard bool) {
+	if !element.enabled { return }
+	if element.open != nil { element.open.close() }
+	element.selected = index
+	element.entity.Invalidate()
+
+	item := element.items[index]
+	if !item.Activatable() { return }
+	if item.Submenu == nil {
+		element.items.Activate(index)
+		return
+	}
+
+	window := element.entity.Window()
+	if window == nil { return }
+	titleBounds := element.titleBounds(index)
+	pane, err := openMenuPane (
+		window,
+		image.Pt(titleBounds.Min.X, element.entity.Bounds().Max.Y),
+		item.Submenu, nil)
+	if err != nil { return }
+
+	pane.onNavigate = element.navigate
+	pane.onHover    = element.hover
+	pane.onClose    = func () {
+		if element.open != pane { return }
+		element.open = nil
+		element.entity.Invalidate()
+	}
+	element.open = pane
+	if keyboard { pane.step(1) }
+}
+
+// navigate opens the menu next to the currently selected one in the specified
+// direction.
+func (element *MenuBar) navigate (direction int) {
+	index := element.next(element.selected, direction)
+	if index < 0 { return }
+	element.openMenu(index, true)
+}
+
+// hover switches to a different menu if the pointer is moved over its title
+// while another menu is open.
+func (element *MenuBar) hover (position image.Point) {
+	index := element.titleAt(position)
+	if index < 0 || index == element.selected { return }
+	if element.items[index].Submenu == nil { return }
+	element.openMenu(index, false)
+}
+
+// next returns the index of the next activatable title after index in the
+// specified direction, wrapping around if necessary. If there is none, it
+// returns -1.
+func (element *MenuBar) next (index, direction int) int {
+	count := len(element.items)
+	for range element.items {
+		index += direction
+		if index < 0      { index = count - 1 }
+		if index >= count { index = 0 }
+		if element.items[index].Activatable() { return index }
+	}
+	return -1
+}
+
+func (element *MenuBar) titleBounds (index int) image.Rectangle {
+	padding := element.entity.Theme().Padding(tomo.PatternRaised, menuBarCase)
+	inner   := padding.Apply(element.entity.Bounds())
+	x := inner.Min.X
+	for current := 0; current < index; current ++ {
+		x += element.titleWidth(current)
+	}
+	return image.Rect(x, inner.Min.Y, x + element.titleWidth(index), inner.Max.Y)
+}
+
+func (element *MenuBar) titleWidth (index int) int {
+	padding := element.entity.Theme().Padding(tomo.PatternTableCell, menuBarItemCase)
+	return element.rows[index].drawer.LayoutBounds().Dx() + padding.Horizontal()
+}
+
+func (element *MenuBar) titleAt (position image.Point) int {
+	for index := range element.items {
+		if position.In(element.titleBounds(index)) {
+			if !element.items[index].Activatable() { return -1 }
+			return index
+		}
+	}
+	return -1
+}
+
+func (element *MenuBar) updateFaces () {
+	face := element.entity.Theme().FontFace (
+		tomo.FontStyleRegular,
+		tomo.FontSizeNormal,
+		menuBarItemCase)
+	for index := range element.rows {
+		element.rows[index].drawer.SetFace(face)
+	}
+}
+
+func (element *MenuBar) updateMinimumSize () {
+	theme   := element.entity.Theme()
+	padding := theme.Padding(tomo.PatternRaised, menuBarCase)
+	titlePadding := theme.Padding(tomo.PatternTableCell, menuBarItemCase)
+
+	width  := 0
+	height := 0
+	for index := range element.items {
+		width += element.titleWidth(index)
+		lineHeight := element.rows[index].drawer.LineHeight().Round()
+		if lineHeight > height { height = lineHeight }
+	}
+	height += titlePadding.Vertical()
+
+	element.entity.SetMinimumSize (
+		width  + padding.Horizontal(),
+		height + padding.Vertical())
+}
@@ -0,0 +1,49 @@
+package elements
+
+import "image"
+import "image/color"
+import "art"
+import "tomo/textdraw"
+
+// labelText is the text of a control's label. Mnemonics are opt-in, so the text
+// is only searched for one if it was set with one of the SetTextWithMnemonic
+// methods. Otherwise, underscores are displayed like any other character.
+type labelText struct {
+	text     string
+	parsed   bool
+	mnemonic rune
+	index    int
+}
+
+// set changes the text, searching it for a mnemonic if parse is true. It
+// returns the runes that should be displayed, and whether anything changed.
+func (label *labelText) set (text string, parse bool) (display []rune, changed bool) {
+	if label.text == text && label.parsed == parse { return nil, false }
+	label.text   = text
+	label.parsed = parse
+	if parse {
+		display, label.mnemonic, label.index = textdraw.ParseMnemonic(text)
+	} else {
+		display, label.mnemonic, label.index = []rune(text), 0, -1
+	}
+	return display, true
+}
+
+// String returns the text as it is displayed, without any mnemonic markup.
+func (label labelText) String () string {
+	if !label.parsed { return label.text }
+	display, _, _ := textdraw.ParseMnemonic(label.text)
+	return string(display)
+}
+
+// underline draws a line underneath the mnemonic, if there is one. The offset
+// should be the same one that the text was drawn at.
+func (label labelText) underline (
+	drawer      textdraw.Drawer,
+	destination art.Canvas,
+	color       color.RGBA,
+	offset      image.Point,
+) {
+	if label.mnemonic == 0 { return }
+	drawer.DrawUnderline(destination, color, offset, label.index)
+}
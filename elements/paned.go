@@ -0,0 +1,424 @@
+package elements
+
+import "image"
+import "tomo"
+import "tomo/input"
+import "art"
+import "art/shatter"
+
+var panedCase = tomo.C("tomo", "paned")
+
+// PaneSide specifies one of the two sides of a Paned container.
+type PaneSide int; const (
+	// PaneNone refers to neither side.
+	PaneNone PaneSide = iota
+
+	// PaneFirst refers to the left or top side.
+	PaneFirst
+
+	// PaneSecond refers to the right or bottom side.
+	PaneSecond
+)
+
+// Paned is a container that holds two elements side by side, separated by a
+// divider that can be dragged to change how much space each one gets. The
+// divider can also be moved using the arrow keys when the Paned has keyboard
+// focus. Each side can be made collapsible, in which case dragging the divider
+// far enough towards it hides it completely.
+type Paned struct {
+	entity tomo.Entity
+
+	first    tomo.Element
+	second   tomo.Element
+	vertical bool
+	enabled  bool
+
+	position    float64
+	collapsed   PaneSide
+	collapsible [2]bool
+
+	// childMinimums remembers the minimum size of each side along the
+	// axis of the divider, so that the collapse thresholds stay the same
+	// while a side is collapsed and its element is not adopted.
+	childMinimums [2]int
+
+	divider    image.Rectangle
+	dragging   bool
+	dragOffset int
+
+	onResize func ()
+}
+
+// NewHPaned creates a new paned container with its elements arranged left to
+// right.
+func NewHPaned (first, second tomo.Element) (element *Paned) {
+	element = &Paned { position: 0.5, enabled: true }
+	element.entity = tomo.GetBackend().NewEntity(element)
+	element.first  = first
+	element.second = second
+	element.adoptChildren()
+	return
+}
+
+// NewVPaned creates a new paned container with its elements arranged top to
+// bottom.
+func NewVPaned (first, second tomo.Element) (element *Paned) {
+	element = &Paned { position: 0.5, enabled: true, vertical: true }
+	element.entity = tomo.GetBackend().NewEntity(element)
+	element.first  = first
+	element.second = second
+	element.adoptChildren()
+	return
+}
+
+// Entity returns this element's entity.
+func (element *Paned) Entity () tomo.Entity {
+	return element.entity
+}
+
+// Draw causes the element to draw to the specified destination canvas.
+func (element *Paned) Draw (destination art.Canvas) {
+	rocks := make([]image.Rectangle, element.entity.CountChildren() + 1)
+	for index := 0; index < element.entity.CountChildren(); index ++ {
+		rocks[index] = element.entity.Child(index).Entity().Bounds()
+	}
+	rocks[len(rocks) - 1] = element.divider
+
+	tiles := shatter.Shatter(element.entity.Bounds(), rocks...)
+	for _, tile := range tiles {
+		element.entity.DrawBackground(art.Cut(destination, tile))
+	}
+
+	state := tomo.State {
+		Disabled: !element.Enabled(),
+		Focused:  element.entity.Focused(),
+		Pressed:  element.dragging,
+	}
+	pattern := element.entity.Theme().Pattern(tomo.PatternHandle, state, panedCase)
+	pattern.Draw(art.Cut(destination, element.divider), element.divider)
+}
+
+// Layout causes this element to perform a layout operation.
+func (element *Paned) Layout () {
+	bounds := element.entity.Bounds()
+	start, end := element.axis(bounds.Min), element.axis(bounds.Max)
+	firstSize := element.firstSize()
+	split := start + firstSize
+	thickness := element.thickness()
+
+	var firstBounds, secondBounds image.Rectangle
+	if element.vertical {
+		firstBounds     = image.Rect(bounds.Min.X, start, bounds.Max.X, split)
+		element.divider = image.Rect(bounds.Min.X, split, bounds.Max.X, split + thickness)
+		secondBounds    = image.Rect(bounds.Min.X, split + thickness, bounds.Max.X, end)
+	} else {
+		firstBounds     = image.Rect(start, bounds.Min.Y, split, bounds.Max.Y)
+		element.divider = image.Rect(split, bounds.Min.Y, split + thickness, bounds.Max.Y)
+		secondBounds    = image.Rect(split + thickness, bounds.Min.Y, end, bounds.Max.Y)
+	}
+
+	if index := element.entity.IndexOf(element.first); element.shown(PaneFirst) && index >= 0 {
+		element.entity.PlaceChild(index, firstBounds)
+	}
+	if index := element.entity.IndexOf(element.second); element.shown(PaneSecond) && index >= 0 {
+		element.entity.PlaceChild(index, secondBounds)
+	}
+}
+
+// DrawBackground draws this element's background pattern to the specified
+// destination canvas.
+func (element *Paned) DrawBackground (destination art.Canvas) {
+	element.entity.DrawBackground(destination)
+}
+
+// SetFirst sets the element on the left or top side. If nil is passed, that
+// side is left empty.
+func (element *Paned) SetFirst (child tomo.Element) {
+	element.first = child
+	element.childMinimums[0] = 0
+	element.adoptChildren()
+}
+
+// SetSecond sets the element on the right or bottom side. If nil is passed,
+// that side is left empty.
+func (element *Paned) SetSecond (child tomo.Element) {
+	element.second = child
+	element.childMinimums[1] = 0
+	element.adoptChildren()
+}
+
+// First returns the element on the left or top side.
+func (element *Paned) First () tomo.Element {
+	return element.first
+}
+
+// Second returns the element on the right or bottom side.
+func (element *Paned) Second () tomo.Element {
+	return element.second
+}
+
+// Position returns the position of the divider, from zero to one. This is the
+// proportion of the available space that is given to the first side. It can be
+// saved and later restored with SetPosition. If a side is collapsed, this
+// is where the divider will return to when it is expanded again.
+func (element *Paned) Position () float64 {
+	return element.position
+}
+
+// SetPosition sets the position of the divider, from zero to one. The divider
+// is kept far enough from the edges that both elements can fit their minimum
+// sizes.
+func (element *Paned) SetPosition (position float64) {
+	if position < 0 { position = 0 }
+	if position > 1 { position = 1 }
+	if element.position == position { return }
+	element.position = position
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+// SetCollapsible sets whether each side can be collapsed by dragging the
+// divider towards it.
+func (element *Paned) SetCollapsible (first, second bool) {
+	element.collapsible = [2]bool { first, second }
+}
+
+// Collapsed returns which side is collapsed, if any.
+func (element *Paned) Collapsed () PaneSide {
+	return element.collapsed
+}
+
+// SetCollapsed collapses the specified side, hiding it. Passing PaneNone
+// expands any collapsed side. This works even if the side is not collapsible.
+func (element *Paned) SetCollapsed (side PaneSide) {
+	if element.collapsed == side { return }
+	element.collapsed = side
+	element.adoptChildren()
+}
+
+// OnResize sets a function to be called when the user moves the divider, or
+// collapses or expands one of the sides.
+func (element *Paned) OnResize (callback func ()) {
+	element.onResize = callback
+}
+
+// Focus gives this element input focus.
+func (element *Paned) Focus () {
+	if !element.entity.Focused() { element.entity.Focus() }
+}
+
+// Enabled returns whether the divider can be moved.
+func (element *Paned) Enabled () bool {
+	return element.enabled
+}
+
+// SetEnabled sets whether the divider can be moved.
+func (element *Paned) SetEnabled (enabled bool) {
+	if element.enabled == enabled { return }
+	element.enabled = enabled
+	element.entity.Invalidate()
+}
+
+func (element *Paned) HandleFocusChange () {
+	element.entity.Invalidate()
+}
+
+func (element *Paned) HandleMouseDown (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) {
+	if !element.Enabled() || button != input.ButtonLeft { return }
+	if !position.In(element.divider) { return }
+	element.Focus()
+	element.dragging = true
+	element.dragOffset = element.axis(position) - element.axis(element.divider.Min)
+	element.entity.Invalidate()
+}
+
+func (element *Paned) HandleMouseUp (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) {
+	if button != input.ButtonLeft || !element.dragging { return }
+	element.dragging = false
+	element.entity.Invalidate()
+}
+
+func (element *Paned) HandleMotion (position image.Point) {
+	if !element.dragging { return }
+	start := element.axis(element.entity.Bounds().Min)
+	element.moveDivider(element.axis(position) - element.dragOffset - start)
+}
+
+func (element *Paned) HandleKeyDown (key input.Key, modifiers input.Modifiers) {
+	if !element.Enabled() { return }
+	step := element.thickness() * 4
+	if modifiers.Shift { step *= 4 }
+
+	var backward, forward input.Key = input.KeyLeft, input.KeyRight
+	if element.vertical { backward, forward = input.KeyUp, input.KeyDown }
+
+	switch key {
+	case backward:
+		element.moveDivider(element.firstSize() - step)
+	case forward:
+		element.moveDivider(element.firstSize() + step)
+	case input.KeyHome:
+		element.moveDivider(0)
+	case input.KeyEnd:
+		element.moveDivider(element.available())
+	}
+}
+
+func (element *Paned) HandleKeyUp (key input.Key, modifiers input.Modifiers) { }
+
+func (element *Paned) HandleChildMinimumSizeChange (child tomo.Element) {
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+func (element *Paned) HandleThemeChange () {
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+// moveDivider moves the divider so that the first side is the specified size,
+// collapsing or expanding sides as necessary.
+func (element *Paned) moveDivider (size int) {
+	available := element.available()
+	element.minimums()
+	firstMinimum  := element.childMinimums[0]
+	secondMinimum := element.childMinimums[1]
+
+	collapsed := PaneNone
+	if element.collapsible[0] && size < firstMinimum / 2 {
+		collapsed = PaneFirst
+	} else if element.collapsible[1] && available - size < secondMinimum / 2 {
+		collapsed = PaneSecond
+	}
+
+	if collapsed == PaneNone && available > 0 {
+		size = clampSplit(size, available, firstMinimum, secondMinimum)
+		element.position = float64(size) / float64(available)
+	}
+	if collapsed != element.collapsed {
+		element.collapsed = collapsed
+		element.adoptChildren()
+	} else {
+		element.entity.Invalidate()
+		element.entity.InvalidateLayout()
+	}
+	if element.onResize != nil {
+		element.onResize()
+	}
+}
+
+// adoptChildren makes sure that only the elements that are not collapsed are
+// children of the entity.
+func (element *Paned) adoptChildren () {
+	for element.entity.CountChildren() > 0 {
+		element.entity.Disown(0)
+	}
+	if element.first != nil && element.shown(PaneFirst) {
+		element.entity.Adopt(element.first)
+	}
+	if element.second != nil && element.shown(PaneSecond) {
+		element.entity.Adopt(element.second)
+	}
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+func (element *Paned) shown (side PaneSide) bool {
+	return element.collapsed != side
+}
+
+// firstSize returns the size of the first side along the axis of the divider.
+func (element *Paned) firstSize () int {
+	available := element.available()
+	switch element.collapsed {
+	case PaneFirst:  return 0
+	case PaneSecond: return available
+	}
+	firstMinimum, secondMinimum := element.minimums()
+	return clampSplit (
+		int(element.position * float64(available)),
+		available, firstMinimum, secondMinimum)
+}
+
+// available returns the amount of space shared by both sides.
+func (element *Paned) available () int {
+	bounds := element.entity.Bounds()
+	available := element.axis(bounds.Size()) - element.thickness()
+	if available < 0 { available = 0 }
+	return available
+}
+
+// minimums returns the minimum sizes of both sides along the axis of the
+// divider. Collapsed sides have a minimum size of zero. The minimum sizes of
+// shown sides are also stored in childMinimums.
+func (element *Paned) minimums () (first, second int) {
+	measure := func (side int, child tomo.Element) int {
+		index := element.entity.IndexOf(child)
+		if child == nil || index < 0 { return 0 }
+		width, height := element.entity.ChildMinimumSize(index)
+		element.childMinimums[side] = element.axis(image.Pt(width, height))
+		return element.childMinimums[side]
+	}
+	return measure(0, element.first), measure(1, element.second)
+}
+
+func (element *Paned) thickness () int {
+	padding := element.entity.Theme().Padding(tomo.PatternHandle, panedCase)
+	if element.vertical {
+		return padding.Vertical()
+	} else {
+		return padding.Horizontal()
+	}
+}
+
+// axis returns the component of a point along the axis that the elements are
+// laid out on.
+func (element *Paned) axis (point image.Point) int {
+	if element.vertical {
+		return point.Y
+	} else {
+		return point.X
+	}
+}
+
+func (element *Paned) updateMinimumSize () {
+	var size, breadth int
+	for index := 0; index < element.entity.CountChildren(); index ++ {
+		width, height := element.entity.ChildMinimumSize(index)
+		var childSize, childBreadth int; if element.vertical {
+			childSize, childBreadth = height, width
+		} else {
+			childSize, childBreadth = width, height
+		}
+		size += childSize
+		if childBreadth > breadth { breadth = childBreadth }
+	}
+	size += element.thickness()
+
+	if element.vertical {
+		element.entity.SetMinimumSize(breadth, size)
+	} else {
+		element.entity.SetMinimumSize(size, breadth)
+	}
+}
+
+// clampSplit keeps the size of the first side within the range that allows
+// both sides to fit their minimum sizes. If there isn't enough room for both,
+// the first side is favored.
+func clampSplit (size, available, firstMinimum, secondMinimum int) int {
+	if size > available - secondMinimum { size = available - secondMinimum }
+	if size < firstMinimum              { size = firstMinimum }
+	if size > available                 { size = available }
+	return size
+}
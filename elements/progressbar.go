@@ -1,26 +1,58 @@
 package elements
 
+import "fmt"
+import "time"
 import "image"
 import "tomo"
 import "art"
+import "tomo/textdraw"
 
-var progressBarCase = tomo.C("tomo", "progressBar")
-
-// ProgressBar displays a visual indication of how far along a task is.
+// ProgressBar displays a visual indication of how far along a task is. If the
+// length of the task is not known, the bar can be put into an indeterminate
+// mode where it displays an animated sweep instead of a fixed level.
 type ProgressBar struct {
 	entity tomo.Entity
-	progress float64
+	drawer textdraw.Drawer
+
+	c tomo.Case
+
+	progress      float64
+	vertical      bool
+	indeterminate bool
+	sweep         float64
+	animation     animation
+
+	format func (progress float64) string
 }
 
-// NewProgressBar creates a new progress bar displaying the given progress
-// level.
+// NewVProgressBar creates a new vertical progress bar displaying the given
+// progress level. It fills from the bottom up.
+func NewVProgressBar (progress float64) (element *ProgressBar) {
+	element = &ProgressBar { vertical: true }
+	element.c = tomo.C("tomo", "progressBarVertical")
+	element.construct(progress)
+	return
+}
+
+// NewProgressBar creates a new horizontal progress bar displaying the given
+// progress level.
 func NewProgressBar (progress float64) (element *ProgressBar) {
+	element = &ProgressBar { }
+	element.c = tomo.C("tomo", "progressBar")
+	element.construct(progress)
+	return
+}
+
+func (element *ProgressBar) construct (progress float64) {
 	if progress < 0 { progress = 0 }
 	if progress > 1 { progress = 1 }
-	element = &ProgressBar { progress: progress }
+	element.progress = progress
 	element.entity = tomo.GetBackend().NewEntity(element)
+	element.drawer.SetFace (element.entity.Theme().FontFace (
+		tomo.FontStyleRegular,
+		tomo.FontSizeNormal,
+		element.c))
 	element.updateMinimumSize()
-	return
 }
 
 // Entity returns this element's entity.
@@ -30,18 +62,44 @@ func (element *ProgressBar) Entity () tomo.Entity {
 
 // Draw causes the element to draw to the specified destination canvas.
 func (element *ProgressBar) Draw (destination art.Canvas) {
+	if element.indeterminate {
+		// the animation stops when the bar is taken out of its window,
+		// so it must be started again if it is put back
+		element.animation.start (
+			element.entity, time.Second / 24,
+			element.step)
+	}
+
 	bounds := element.entity.Bounds()
 
-	pattern := element.entity.Theme().Pattern(tomo.PatternSunken, tomo.State { }, progressBarCase)
-	padding := element.entity.Theme().Padding(tomo.PatternSunken, progressBarCase)
+	pattern := element.entity.Theme().Pattern(tomo.PatternSunken, tomo.State { }, element.c)
+	padding := element.entity.Theme().Padding(tomo.PatternSunken, element.c)
 	pattern.Draw(destination, bounds)
 	bounds = padding.Apply(bounds)
-	meterBounds := image.Rect (
-		bounds.Min.X, bounds.Min.Y,
-		bounds.Min.X + int(float64(bounds.Dx()) * element.progress),
-		bounds.Max.Y)
-	mercury := element.entity.Theme().Pattern(tomo.PatternMercury, tomo.State { }, progressBarCase)
+
+	var meterBounds image.Rectangle
+	if element.indeterminate {
+		meterBounds = element.sweepBounds(bounds)
+	} else {
+		meterBounds = element.meterBounds(bounds)
+	}
+	mercury := element.entity.Theme().Pattern(tomo.PatternMercury, tomo.State { }, element.c)
 	mercury.Draw(destination, meterBounds)
+
+	if element.format != nil {
+		textBounds := element.drawer.LayoutBounds()
+		offset := image.Pt (
+			bounds.Dx() / 2,
+			bounds.Dy() / 2).Add(bounds.Min)
+		offset.X -= textBounds.Dx() / 2
+		offset.Y -= textBounds.Dy() / 2
+		offset.Y -= textBounds.Min.Y
+		offset.X -= textBounds.Min.X
+		foreground := element.entity.Theme().Color (
+			tomo.ColorForeground,
+			tomo.State { }, element.c)
+		element.drawer.Draw(destination, foreground, offset)
+	}
 }
 
 // SetProgress sets the progress level of the bar.
@@ -50,18 +108,133 @@ func (element *ProgressBar) SetProgress (progress float64) {
 	if progress > 1 { progress = 1 }
 	if progress == element.progress { return }
 	element.progress = progress
+	element.updateText()
+	element.entity.Invalidate()
+}
+
+// Progress returns the progress level of the bar.
+func (element *ProgressBar) Progress () float64 {
+	return element.progress
+}
+
+// SetIndeterminate sets whether the bar is in indeterminate mode. An
+// indeterminate bar ignores its progress level and instead displays an
+// animated sweep, which is useful for tasks who's duration is not known. The
+// sweep only moves while the bar is in a window.
+func (element *ProgressBar) SetIndeterminate (indeterminate bool) {
+	if element.indeterminate == indeterminate { return }
+	element.indeterminate = indeterminate
+	element.sweep = 0
+	if !indeterminate {
+		element.animation.stop()
+	}
+	element.entity.Invalidate()
+}
+
+// Indeterminate returns whether the bar is in indeterminate mode.
+func (element *ProgressBar) Indeterminate () bool {
+	return element.indeterminate
+}
+
+// SetFormat sets a function that is used to generate text that is displayed
+// on top of the bar. It is called with the current progress level whenever it
+// changes. Passing nil removes the text. FormatPercent can be used to display
+// the progress level as a percentage.
+func (element *ProgressBar) SetFormat (format func (progress float64) string) {
+	element.format = format
+	element.updateText()
 	element.entity.Invalidate()
 }
 
+// SetText sets static text to be displayed on top of the bar. This is a
+// shorthand for calling SetFormat with a function that always returns the
+// given text. Passing an empty string removes the text.
+func (element *ProgressBar) SetText (text string) {
+	if text == "" {
+		element.SetFormat(nil)
+	} else {
+		element.SetFormat(func (float64) string { return text })
+	}
+}
+
+// FormatPercent formats a progress level as a percentage. It can be passed to
+// ProgressBar.SetFormat.
+func FormatPercent (progress float64) string {
+	return fmt.Sprint(int(progress * 100), "%")
+}
+
 func (element *ProgressBar) HandleThemeChange () {
+	element.drawer.SetFace (element.entity.Theme().FontFace (
+		tomo.FontStyleRegular,
+		tomo.FontSizeNormal,
+		element.c))
 	element.updateMinimumSize()
 	element.entity.Invalidate()
 }
 
+func (element *ProgressBar) meterBounds (bounds image.Rectangle) image.Rectangle {
+	if element.vertical {
+		return image.Rect (
+			bounds.Min.X,
+			bounds.Max.Y - int(float64(bounds.Dy()) * element.progress),
+			bounds.Max.X, bounds.Max.Y)
+	} else {
+		return image.Rect (
+			bounds.Min.X, bounds.Min.Y,
+			bounds.Min.X + int(float64(bounds.Dx()) * element.progress),
+			bounds.Max.Y)
+	}
+}
+
+func (element *ProgressBar) sweepBounds (bounds image.Rectangle) image.Rectangle {
+	// the sweep travels back and forth across the track, taking up a
+	// quarter of it
+	position := element.sweep * 2
+	if position > 1 { position = 2 - position }
+
+	if element.vertical {
+		size   := bounds.Dy() / 4
+		offset := int(float64(bounds.Dy() - size) * (1 - position))
+		return image.Rect (
+			bounds.Min.X, bounds.Min.Y + offset,
+			bounds.Max.X, bounds.Min.Y + offset + size)
+	} else {
+		size   := bounds.Dx() / 4
+		offset := int(float64(bounds.Dx() - size) * position)
+		return image.Rect (
+			bounds.Min.X + offset, bounds.Min.Y,
+			bounds.Min.X + offset + size, bounds.Max.Y)
+	}
+}
+
+func (element *ProgressBar) step () {
+	element.sweep += 1.0 / 48
+	if element.sweep >= 1 { element.sweep -= 1 }
+	element.entity.Invalidate()
+}
+
+func (element *ProgressBar) updateText () {
+	if element.format == nil {
+		element.drawer.SetText(nil)
+	} else {
+		element.drawer.SetText([]rune(element.format(element.progress)))
+	}
+	element.updateMinimumSize()
+}
+
 func (element *ProgressBar) updateMinimumSize() {
-	padding      := element.entity.Theme().Padding(tomo.PatternSunken, progressBarCase)
-	innerPadding := element.entity.Theme().Padding(tomo.PatternMercury, progressBarCase)
+	padding      := element.entity.Theme().Padding(tomo.PatternSunken, element.c)
+	innerPadding := element.entity.Theme().Padding(tomo.PatternMercury, element.c)
+	width  := innerPadding.Horizontal()
+	height := innerPadding.Vertical()
+
+	if element.format != nil {
+		textBounds := element.drawer.LayoutBounds()
+		if textBounds.Dx() > width  { width  = textBounds.Dx() }
+		if textBounds.Dy() > height { height = textBounds.Dy() }
+	}
+
 	element.entity.SetMinimumSize (
-		padding.Horizontal() + innerPadding.Horizontal(),
-		padding.Vertical()   + innerPadding.Vertical())
+		padding.Horizontal() + width,
+		padding.Vertical()   + height)
 }
@@ -0,0 +1,102 @@
+package elements
+
+import "image"
+import "tomo"
+import "tomo/input"
+import "art"
+
+var radioButtonCase = tomo.C("tomo", "radioButton")
+
+// RadioButton is a selectable button with a label. It is meant to be used
+// with a RadioGroup, which ensures that only one of its buttons is selected at
+// a time. Unlike a Checkbox, clicking a selected radio button does not
+// deselect it.
+type RadioButton struct {
+	checkable
+
+	selected bool
+
+	group    radioGroup
+	onToggle func ()
+}
+
+// NewRadioButton creates a new radio button with the specified label text.
+func NewRadioButton (text string, selected bool) (element *RadioButton) {
+	element = &RadioButton { selected: selected }
+	element.construct(element, radioButtonCase, text)
+	return
+}
+
+// Draw causes the element to draw to the specified destination canvas.
+func (element *RadioButton) Draw (destination art.Canvas) {
+	state := element.state()
+	state.On = element.selected
+	element.draw(destination, state)
+}
+
+// OnToggle sets the function to be called when the radio button is selected.
+func (element *RadioButton) OnToggle (callback func ()) {
+	element.onToggle = callback
+}
+
+// Value reports whether or not the radio button is currently selected.
+func (element *RadioButton) Value () (selected bool) {
+	return element.selected
+}
+
+// SetValue sets whether or not the radio button is selected. This does not
+// affect other buttons in its group, and does not call the OnToggle callback.
+// To select a button and deselect the rest of the group, use RadioGroup.Select.
+func (element *RadioButton) SetValue (selected bool) {
+	if element.selected == selected { return }
+	element.selected = selected
+	element.entity.Invalidate()
+}
+
+func (element *RadioButton) HandleMnemonic () {
+	element.Focus()
+	element.activate()
+}
+
+func (element *RadioButton) HandleMouseUp (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) {
+	if element.release(position, button) {
+		element.activate()
+	}
+}
+
+func (element *RadioButton) HandleKeyDown (key input.Key, modifiers input.Modifiers) {
+	if !element.Enabled() { return }
+	if key == input.KeyEnter || key == ' ' {
+		element.pressed = true
+		element.entity.Invalidate()
+	} else if element.group != nil {
+		element.group.handleArrowKey(element, key)
+	}
+}
+
+func (element *RadioButton) HandleKeyUp (key input.Key, modifiers input.Modifiers) {
+	if (key == input.KeyEnter || key == ' ') && element.pressed {
+		element.pressed = false
+		element.entity.Invalidate()
+		element.activate()
+	}
+}
+
+func (element *RadioButton) setGroup (group radioGroup) (previous radioGroup) {
+	previous = element.group
+	element.group = group
+	return
+}
+
+// activate selects the radio button in response to user input.
+func (element *RadioButton) activate () {
+	if element.selected { return }
+	element.selected = true
+	element.entity.Invalidate()
+	if element.group != nil { element.group.handleSelect(element) }
+	if element.onToggle != nil { element.onToggle() }
+}
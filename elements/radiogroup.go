@@ -0,0 +1,146 @@
+package elements
+
+import "tomo/input"
+
+// RadioMember is an element that can be added to a RadioGroup. RadioButton and
+// ToggleButton both satisfy this interface.
+type RadioMember interface {
+	Value () bool
+	SetValue (bool)
+	Focus ()
+	Enabled () bool
+
+	// setGroup sets the group the member is in, and returns the group it
+	// was in before.
+	setGroup (radioGroup) (previous radioGroup)
+	activate ()
+}
+
+type radioGroup interface {
+	handleSelect (member RadioMember)
+	handleArrowKey (member RadioMember, key input.Key)
+	remove (member RadioMember) (removed bool)
+}
+
+type radioEntry[T comparable] struct {
+	member RadioMember
+	value  T
+}
+
+// RadioGroup ensures that only one of its members is selected at a time, and
+// associates each member with a value. Arrow keys can be used to move the
+// selection between members of the group.
+type RadioGroup[T comparable] struct {
+	entries  []radioEntry[T]
+	onChange func ()
+}
+
+// NewRadioGroup creates a new, empty radio group.
+func NewRadioGroup[T comparable] () *RadioGroup[T] {
+	return &RadioGroup[T] { }
+}
+
+// Add adds a member to the group, associating it with the specified value. If
+// the member is already selected, every other member of the group is
+// deselected. A member may only be in one group at a time, so if it is already
+// in another group, it is removed from that group first.
+func (group *RadioGroup[T]) Add (member RadioMember, value T) {
+	group.remove(member)
+	previous := member.setGroup(group)
+	if previous != nil && previous != radioGroup(group) {
+		previous.remove(member)
+	}
+	group.entries = append(group.entries, radioEntry[T] { member, value })
+	if member.Value() { group.deselectOthers(member) }
+}
+
+// Remove removes a member from the group.
+func (group *RadioGroup[T]) Remove (member RadioMember) {
+	if group.remove(member) { member.setGroup(nil) }
+}
+
+// Value returns the value associated with the selected member. If no member is
+// selected, ok will be false.
+func (group *RadioGroup[T]) Value () (value T, ok bool) {
+	for _, entry := range group.entries {
+		if entry.member.Value() { return entry.value, true }
+	}
+	return
+}
+
+// Selected returns the selected member, or nil if no member is selected.
+func (group *RadioGroup[T]) Selected () RadioMember {
+	for _, entry := range group.entries {
+		if entry.member.Value() { return entry.member }
+	}
+	return nil
+}
+
+// Select selects the first member associated with the specified value, and
+// deselects the rest. If no member has that value, every member is
+// deselected. This does not call the OnChange callback.
+func (group *RadioGroup[T]) Select (value T) {
+	found := false
+	for _, entry := range group.entries {
+		selected := !found && entry.value == value
+		if selected { found = true }
+		entry.member.SetValue(selected)
+	}
+}
+
+// OnChange sets the function to be called when the user changes which member
+// of the group is selected.
+func (group *RadioGroup[T]) OnChange (callback func ()) {
+	group.onChange = callback
+}
+
+func (group *RadioGroup[T]) handleSelect (member RadioMember) {
+	group.deselectOthers(member)
+	if group.onChange != nil { group.onChange() }
+}
+
+func (group *RadioGroup[T]) handleArrowKey (member RadioMember, key input.Key) {
+	direction := 0
+	switch key {
+	case input.KeyUp,   input.KeyLeft:  direction = -1
+	case input.KeyDown, input.KeyRight: direction = 1
+	default: return
+	}
+
+	current := -1
+	for index, entry := range group.entries {
+		if entry.member == member { current = index; break }
+	}
+	if current < 0 { return }
+
+	count := len(group.entries)
+	index := current
+	for range group.entries {
+		index = (index + direction + count) % count
+		if index == current { return }
+		next := group.entries[index].member
+		if !next.Enabled() { continue }
+		next.Focus()
+		next.activate()
+		return
+	}
+}
+
+// remove removes a member's entry from the group without telling the member.
+func (group *RadioGroup[T]) remove (member RadioMember) (removed bool) {
+	for index, entry := range group.entries {
+		if entry.member != member { continue }
+		group.entries = append (
+			group.entries[:index],
+			group.entries[index + 1:]...)
+		return true
+	}
+	return false
+}
+
+func (group *RadioGroup[T]) deselectOthers (member RadioMember) {
+	for _, entry := range group.entries {
+		if entry.member == member { continue }
+		entry.member.SetValue(false)
+	}
+}
@@ -0,0 +1,120 @@
+package elements
+
+import "testing"
+import "tomo/input"
+
+// fakeMember is a radio group member that doesn't need a backend.
+type fakeMember struct {
+	selected bool
+	disabled bool
+	focused  bool
+	group    radioGroup
+}
+
+func (member *fakeMember) Value () bool           { return member.selected }
+func (member *fakeMember) SetValue (value bool)   { member.selected = value }
+func (member *fakeMember) Focus ()                { member.focused = true }
+func (member *fakeMember) Enabled () bool         { return !member.disabled }
+
+func (member *fakeMember) setGroup (group radioGroup) (previous radioGroup) {
+	previous = member.group
+	member.group = group
+	return
+}
+
+func (member *fakeMember) activate () {
+	if member.selected { return }
+	member.selected = true
+	if member.group != nil { member.group.handleSelect(member) }
+}
+
+func TestRadioGroupSelect (test *testing.T) {
+	a, b, c := &fakeMember { }, &fakeMember { selected: true }, &fakeMember { }
+	group := NewRadioGroup[string]()
+	changes := 0
+	group.OnChange(func () { changes ++ })
+	group.Add(a, "a")
+	group.Add(b, "b")
+	group.Add(c, "c")
+
+	if value, ok := group.Value(); !ok || value != "b" {
+		test.Errorf("got %q, %v, expected \"b\", true", value, ok)
+	}
+
+	c.activate()
+	if a.selected || b.selected || !c.selected {
+		test.Error("activating a member did not deselect the others")
+	}
+	if changes != 1 {
+		test.Errorf("OnChange called %d times, expected once", changes)
+	}
+
+	group.Select("a")
+	if group.Selected() != RadioMember(a) || c.selected {
+		test.Error("Select did not select the right member")
+	}
+	group.Select("none")
+	if _, ok := group.Value(); ok {
+		test.Error("selecting a missing value left a member selected")
+	}
+	if changes != 1 { test.Error("Select called OnChange") }
+
+	// adding a selected member deselects the rest
+	group.Select("b")
+	d := &fakeMember { selected: true }
+	group.Add(d, "d")
+	if b.selected { test.Error("adding a selected member kept the old one") }
+}
+
+func TestRadioGroupMembership (test *testing.T) {
+	first  := NewRadioGroup[int]()
+	second := NewRadioGroup[int]()
+	a, b := &fakeMember { }, &fakeMember { }
+	first.Add(a, 1)
+	first.Add(b, 2)
+	second.Add(a, 3)
+
+	if len(first.entries) != 1 || len(second.entries) != 1 {
+		test.Fatal("member was not moved between groups")
+	}
+	if a.group != radioGroup(second) {
+		test.Error("member does not know its new group")
+	}
+
+	first.Add(b, 4)
+	if len(first.entries) != 1 || first.entries[0].value != 4 {
+		test.Error("re-adding a member duplicated it")
+	}
+
+	first.Remove(b)
+	if len(first.entries) != 0 || b.group != nil {
+		test.Error("member was not removed")
+	}
+	first.Remove(a)
+	if a.group != radioGroup(second) {
+		test.Error("removing a member of another group changed it")
+	}
+}
+
+func TestRadioGroupArrowKeys (test *testing.T) {
+	a, b, c := &fakeMember { selected: true }, &fakeMember { disabled: true }, &fakeMember { }
+	group := NewRadioGroup[int]()
+	group.Add(a, 0)
+	group.Add(b, 1)
+	group.Add(c, 2)
+
+	group.handleArrowKey(a, input.KeyDown)
+	if !c.selected || !c.focused || a.selected {
+		test.Error("arrow key did not skip the disabled member")
+	}
+	group.handleArrowKey(c, input.KeyRight)
+	if !a.selected || c.selected {
+		test.Error("arrow key did not wrap around")
+	}
+	group.handleArrowKey(a, input.KeyUp)
+	if !c.selected {
+		test.Error("arrow key did not wrap around backwards")
+	}
+	group.handleArrowKey(c, input.KeyEnter)
+	if !c.selected { test.Error("unrelated key changed the selection") }
+}
@@ -0,0 +1,192 @@
+package elements
+
+import "tomo"
+import "tomo/input"
+import "tomo/ability"
+
+// SelectionMode determines how many items can be selected at once.
+type SelectionMode int; const (
+	// SelectionSingle allows at most one item to be selected at a time.
+	SelectionSingle SelectionMode = iota
+
+	// SelectionMultiple allows any amount of items to be selected. Items
+	// can be added to or removed from the selection by holding Control,
+	// and ranges of items can be selected by holding Shift.
+	SelectionMultiple
+)
+
+// selectionItems is a list of items that a selector can select. Items are
+// referred to by the index they are displayed at. Elements store the
+// selection, cursor, and anchor however is convenient for them, and let a
+// selector change them through this interface.
+type selectionItems interface {
+	// itemCount returns the amount of items.
+	itemCount () int
+
+	// itemSelected returns whether the item at the specified index is
+	// selected.
+	itemSelected (index int) bool
+
+	// selectItem selects or deselects the item at the specified index, and
+	// returns whether that changed anything.
+	selectItem (index int, selected bool) (changed bool)
+
+	// cursorIndex returns the index of the item with the cursor, or -1 if
+	// there is none.
+	cursorIndex () int
+
+	// setCursorIndex moves the cursor to the item at the specified index.
+	setCursorIndex (index int)
+
+	// anchorIndex returns the index of the item that range selections
+	// start from, or -1 if there is none.
+	anchorIndex () int
+
+	// setAnchorIndex makes the item at the specified index the anchor.
+	setAnchorIndex (index int)
+}
+
+// selector changes which items of a list are selected in response to user
+// input, so that every element that has a selection behaves the same way.
+type selector struct {
+	items selectionItems
+	mode  SelectionMode
+
+	onSelectionChange func ()
+}
+
+// setSelection selects each item for which selected returns true, and
+// deselects the rest.
+func (selector *selector) setSelection (selected func (index int) bool) {
+	changed := false
+	for index := 0; index < selector.items.itemCount(); index ++ {
+		if selector.items.selectItem(index, selected(index)) {
+			changed = true
+		}
+	}
+	if changed && selector.onSelectionChange != nil {
+		selector.onSelectionChange()
+	}
+}
+
+// setMode sets the selection mode. If only one item can be selected anymore,
+// every item but the one with the cursor is deselected.
+func (selector *selector) setMode (mode SelectionMode) {
+	if selector.mode == mode { return }
+	selector.mode = mode
+	if mode == SelectionSingle {
+		cursor := selector.items.cursorIndex()
+		selector.setSelection (func (index int) bool {
+			return index == cursor && selector.items.itemSelected(index)
+		})
+	}
+}
+
+// pick moves the cursor to the item at the specified index, and changes the
+// selection depending on which modifier keys are held down. Holding Shift
+// selects a range, and clicking while holding Control toggles a single item.
+// When navigating with the keyboard, holding Control moves the cursor without
+// changing the selection.
+func (selector *selector) pick (index int, modifiers input.Modifiers, keyboard bool) {
+	if index < 0 || index >= selector.items.itemCount() { return }
+	selector.items.setCursorIndex(index)
+	multiple := selector.mode == SelectionMultiple
+	anchor   := selector.items.anchorIndex()
+
+	switch {
+	case multiple && modifiers.Shift && anchor >= 0:
+		low, high := anchor, index
+		if low > high { low, high = high, low }
+		selector.setSelection (func (current int) bool {
+			return current >= low && current <= high
+		})
+	case multiple && modifiers.Control && keyboard:
+		// only the cursor moves
+	case multiple && modifiers.Control:
+		selector.items.setAnchorIndex(index)
+		selector.setSelection (func (current int) bool {
+			selected := selector.items.itemSelected(current)
+			if current == index { return !selected }
+			return selected
+		})
+	default:
+		selector.items.setAnchorIndex(index)
+		selector.setSelection (func (current int) bool {
+			return current == index
+		})
+	}
+}
+
+// selectAll selects every item, if more than one can be selected.
+func (selector *selector) selectAll () {
+	if selector.mode != SelectionMultiple { return }
+	selector.setSelection(func (int) bool { return true })
+}
+
+// selectNone deselects every item.
+func (selector *selector) selectNone () {
+	selector.setSelection(func (int) bool { return false })
+}
+
+// childItems lets a selector select the children of a container. The selection
+// state itself is stored by the entity.
+type childItems struct {
+	entity tomo.Entity
+	cursor tomo.Element
+	anchor tomo.Element
+}
+
+func (items *childItems) itemCount () int {
+	return items.entity.CountChildren()
+}
+
+func (items *childItems) itemSelected (index int) bool {
+	_, ok := items.entity.Child(index).(ability.Selectable)
+	return ok && items.entity.Child(index).Entity().Selected()
+}
+
+func (items *childItems) selectItem (index int, selected bool) (changed bool) {
+	if _, ok := items.entity.Child(index).(ability.Selectable); !ok { return }
+	if items.itemSelected(index) == selected { return }
+	items.entity.SelectChild(index, selected)
+	return true
+}
+
+func (items *childItems) cursorIndex () int {
+	if items.cursor == nil { return -1 }
+	return items.entity.IndexOf(items.cursor)
+}
+
+func (items *childItems) setCursorIndex (index int) {
+	items.cursor = items.entity.Child(index)
+}
+
+func (items *childItems) anchorIndex () int {
+	if items.anchor == nil { return -1 }
+	return items.entity.IndexOf(items.anchor)
+}
+
+func (items *childItems) setAnchorIndex (index int) {
+	items.anchor = items.entity.Child(index)
+}
+
+// selection returns the selected children, in order.
+func (items *childItems) selection () (selection []ability.Selectable) {
+	for index := 0; index < items.entity.CountChildren(); index ++ {
+		child, ok := items.entity.Child(index).(ability.Selectable)
+		if ok && child.Entity().Selected() {
+			selection = append(selection, child)
+		}
+	}
+	return
+}
+
+// forget clears the cursor and anchor if their children have been removed.
+func (items *childItems) forget () {
+	if items.cursor != nil && items.entity.IndexOf(items.cursor) < 0 {
+		items.cursor = nil
+	}
+	if items.anchor != nil && items.entity.IndexOf(items.anchor) < 0 {
+		items.anchor = nil
+	}
+}
@@ -0,0 +1,107 @@
+package elements
+
+import "testing"
+import "tomo/input"
+
+// fakeItems is a list of items that stores its selection in a slice, so that
+// selectors can be tested without a backend.
+type fakeItems struct {
+	selected []bool
+	cursor   int
+	anchor   int
+}
+
+func newFakeItems (count int) *fakeItems {
+	return &fakeItems { selected: make([]bool, count), cursor: -1, anchor: -1 }
+}
+
+func (items *fakeItems) itemCount () int {
+	return len(items.selected)
+}
+
+func (items *fakeItems) itemSelected (index int) bool {
+	return items.selected[index]
+}
+
+func (items *fakeItems) selectItem (index int, selected bool) (changed bool) {
+	changed = items.selected[index] != selected
+	items.selected[index] = selected
+	return
+}
+
+func (items *fakeItems) cursorIndex () int         { return items.cursor  }
+func (items *fakeItems) setCursorIndex (index int) { items.cursor = index }
+func (items *fakeItems) anchorIndex () int         { return items.anchor  }
+func (items *fakeItems) setAnchorIndex (index int) { items.anchor = index }
+
+func (items *fakeItems) expect (test *testing.T, name string, selected ...int) {
+	test.Helper()
+	expected := make([]bool, len(items.selected))
+	for _, index := range selected { expected[index] = true }
+	for index := range expected {
+		if items.selected[index] != expected[index] {
+			test.Errorf("%s: got %v, expected %v", name, items.selected, expected)
+			return
+		}
+	}
+}
+
+func TestSelectorPick (test *testing.T) {
+	none    := input.Modifiers { }
+	shift   := input.Modifiers { Shift: true }
+	control := input.Modifiers { Control: true }
+
+	items := newFakeItems(6)
+	changes := 0
+	selector := selector {
+		items: items,
+		mode:  SelectionMultiple,
+		onSelectionChange: func () { changes ++ },
+	}
+
+	selector.pick(1, none, false)
+	items.expect(test, "click", 1)
+	selector.pick(4, shift, false)
+	items.expect(test, "shift click", 1, 2, 3, 4)
+	selector.pick(0, shift, false)
+	items.expect(test, "shift click before anchor", 0, 1)
+	selector.pick(3, control, false)
+	items.expect(test, "control click", 0, 1, 3)
+	selector.pick(1, control, false)
+	items.expect(test, "control click selected", 0, 3)
+
+	changes = 0
+	selector.pick(5, control, true)
+	items.expect(test, "control arrow", 0, 3)
+	if items.cursor != 5 { test.Errorf("cursor at %d, expected 5", items.cursor) }
+	if changes != 0 { test.Error("moving the cursor changed the selection") }
+
+	selector.pick(5, shift, true)
+	items.expect(test, "shift arrow", 1, 2, 3, 4, 5)
+
+	selector.pick(-1, none, false)
+	selector.pick(6, none, false)
+	items.expect(test, "out of range", 1, 2, 3, 4, 5)
+}
+
+func TestSelectorSingle (test *testing.T) {
+	items := newFakeItems(4)
+	selector := selector { items: items, mode: SelectionMultiple }
+
+	selector.selectAll()
+	items.expect(test, "select all", 0, 1, 2, 3)
+	items.cursor = 2
+	selector.setMode(SelectionSingle)
+	items.expect(test, "switch to single", 2)
+
+	selector.pick(0, input.Modifiers { }, false)
+	selector.pick(3, input.Modifiers { Shift: true }, false)
+	items.expect(test, "shift click", 3)
+	selector.pick(1, input.Modifiers { Control: true }, false)
+	items.expect(test, "control click", 1)
+
+	selector.selectAll()
+	items.expect(test, "select all", 1)
+	selector.selectNone()
+	items.expect(test, "select none")
+}
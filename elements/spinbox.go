@@ -0,0 +1,372 @@
+package elements
+
+import "image"
+import "errors"
+import "strconv"
+import "strings"
+import "tomo"
+import "tomo/input"
+import "tomo/validate"
+import "art"
+
+var spinBoxCase = tomo.C("tomo", "spinBox")
+
+// SpinBox is a text box that only accepts numbers, along with buttons to
+// increment and decrement its value. Its value can be any numeric type, and is
+// always kept between a minimum and maximum value. The value can also be
+// stepped with the arrow keys, Page Up and Page Down, and the scroll wheel.
+type SpinBox[T Numeric] struct {
+	entity tomo.Entity
+
+	input     *TextBox
+	decrement *Button
+	increment *Button
+
+	value T
+	min   T
+	max   T
+	step  T
+
+	precision  int
+	format     func (T) string
+	formatting bool
+	enabled    bool
+
+	onChange func ()
+}
+
+// NewSpinBox creates a new spin box with a minimum and maximum value. The value
+// is stepped by one by default.
+func NewSpinBox[T Numeric] (min, max T, value T) (element *SpinBox[T]) {
+	if min > max { min, max = max, min }
+	element = &SpinBox[T] {
+		min:       min,
+		max:       max,
+		step:      1,
+		precision: -1,
+		enabled:   true,
+	}
+	element.entity = tomo.GetBackend().NewEntity(element)
+
+	element.input = NewTextBox("", "")
+	element.input.SetValidator(element.validate)
+	element.input.OnKeyDown(element.handleInputKey)
+	element.input.OnChange(element.handleInputChange)
+	element.input.OnEnter(element.commit)
+	element.input.OnFocusChange(element.handleInputFocusChange)
+
+	element.decrement = NewButton("")
+	element.decrement.SetIcon(tomo.IconRemove)
+	element.decrement.ShowText(false)
+	element.decrement.OnClick(func () { element.stepBy(-1) })
+
+	element.increment = NewButton("")
+	element.increment.SetIcon(tomo.IconAdd)
+	element.increment.ShowText(false)
+	element.increment.OnClick(func () { element.stepBy(1) })
+
+	element.entity.Adopt(element.input)
+	element.entity.Adopt(element.decrement)
+	element.entity.Adopt(element.increment)
+
+	element.value = element.clamp(value)
+	element.updateText()
+	element.updateMinimumSize()
+	return
+}
+
+// Entity returns this element's entity.
+func (element *SpinBox[T]) Entity () tomo.Entity {
+	return element.entity
+}
+
+// Draw causes the element to draw to the specified destination canvas.
+func (element *SpinBox[T]) Draw (destination art.Canvas) { }
+
+// Layout causes this element to perform a layout operation.
+func (element *SpinBox[T]) Layout () {
+	bounds := element.entity.Bounds()
+	decrementWidth, _ := element.entity.ChildMinimumSize(1)
+	incrementWidth, _ := element.entity.ChildMinimumSize(2)
+
+	input := bounds
+	input.Max.X -= decrementWidth + incrementWidth
+	decrement := image.Rect (
+		input.Max.X, bounds.Min.Y,
+		input.Max.X + decrementWidth, bounds.Max.Y)
+	increment := image.Rect (
+		decrement.Max.X, bounds.Min.Y,
+		bounds.Max.X, bounds.Max.Y)
+
+	element.entity.PlaceChild(0, input)
+	element.entity.PlaceChild(1, decrement)
+	element.entity.PlaceChild(2, increment)
+}
+
+// DrawBackground draws this element's background pattern to the specified
+// destination canvas.
+func (element *SpinBox[T]) DrawBackground (destination art.Canvas) {
+	element.entity.DrawBackground(destination)
+}
+
+func (element *SpinBox[T]) HandleChildMinimumSizeChange (tomo.Element) {
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+func (element *SpinBox[T]) HandleScroll (
+	position image.Point,
+	deltaX, deltaY float64,
+	modifiers input.Modifiers,
+) {
+	if !element.enabled { return }
+	if deltaY < 0 {
+		element.stepBy(1)
+	} else if deltaY > 0 {
+		element.stepBy(-1)
+	}
+}
+
+// Value returns the spin box's value.
+func (element *SpinBox[T]) Value () T {
+	return element.value
+}
+
+// SetValue sets the spin box's value. It is clamped between the minimum and
+// maximum values. This does not call the OnChange callback.
+func (element *SpinBox[T]) SetValue (value T) {
+	element.value = element.clamp(value)
+	element.updateText()
+}
+
+// Range returns the minimum and maximum values of the spin box.
+func (element *SpinBox[T]) Range () (min, max T) {
+	return element.min, element.max
+}
+
+// SetRange sets the minimum and maximum values of the spin box. If the current
+// value falls outside of the new range, it is clamped.
+func (element *SpinBox[T]) SetRange (min, max T) {
+	if min > max { min, max = max, min }
+	element.min = min
+	element.max = max
+	element.SetValue(element.value)
+}
+
+// SetStep sets the amount that the value changes by when it is incremented or
+// decremented. Page Up and Page Down change it by ten times this amount.
+func (element *SpinBox[T]) SetStep (step T) {
+	if step <= 0 { step = 1 }
+	element.step = step
+}
+
+// SetPrecision sets the number of digits displayed after the decimal point. A
+// precision of -1 displays the smallest number of digits necessary. This has
+// no effect on integer types, or when a custom format function is set.
+func (element *SpinBox[T]) SetPrecision (precision int) {
+	element.precision = precision
+	element.updateText()
+}
+
+// SetFormat sets a function that converts the value to the text displayed in
+// the spin box. The text must still be parsable as a number, so that the user
+// can edit it. Passing nil restores the default format.
+func (element *SpinBox[T]) SetFormat (format func (T) string) {
+	element.format = format
+	element.updateText()
+}
+
+// OnChange sets the function to be called when the value is changed by the
+// user.
+func (element *SpinBox[T]) OnChange (callback func ()) {
+	element.onChange = callback
+}
+
+// Focus gives this element input focus.
+func (element *SpinBox[T]) Focus () {
+	element.input.Focus()
+}
+
+// Enabled returns whether this spin box is enabled or not.
+func (element *SpinBox[T]) Enabled () bool {
+	return element.enabled
+}
+
+// SetEnabled sets whether this spin box can be edited or not.
+func (element *SpinBox[T]) SetEnabled (enabled bool) {
+	if element.enabled == enabled { return }
+	element.enabled = enabled
+	element.input.SetEnabled(enabled)
+	element.updateButtons()
+}
+
+func (element *SpinBox[T]) handleInputKey (key input.Key, modifiers input.Modifiers) bool {
+	if !element.enabled { return true }
+	switch key {
+	case input.KeyUp:       element.stepBy(1)
+	case input.KeyDown:     element.stepBy(-1)
+	case input.KeyPageUp:   element.stepBy(10)
+	case input.KeyPageDown: element.stepBy(-10)
+	default: return false
+	}
+	return true
+}
+
+// validate rejects any edit to the input that could not be part of a number,
+// whether it was typed or pasted.
+func (element *SpinBox[T]) validate (value string) (string, error) {
+	signed := element.min < 0
+	if !isFloat[T]() { return validate.Integer(signed)(value) }
+
+	digits := value
+	if signed { digits = strings.TrimPrefix(value, "-") }
+	point := false
+	for _, char := range digits {
+		if char == '.' && !point {
+			point = true
+			continue
+		}
+		if char < '0' || char > '9' { return value, validate.ErrReject }
+	}
+	if strings.Trim(digits, ".") == "" && value != "" {
+		return value, errors.New("expected a number")
+	}
+	return value, nil
+}
+
+func (element *SpinBox[T]) handleInputFocusChange () {
+	if !element.input.Entity().Focused() { element.commit() }
+}
+
+func (element *SpinBox[T]) handleInputChange () {
+	if element.formatting { return }
+	value, ok := element.parse(element.input.Value())
+	if !ok { return }
+	element.change(value)
+}
+
+// commit replaces the text in the input with the formatted value, discarding
+// anything that could not be parsed. This happens when Enter is pressed, and
+// when the input loses focus.
+func (element *SpinBox[T]) commit () {
+	element.handleInputChange()
+	element.updateText()
+}
+
+func (element *SpinBox[T]) stepBy (steps int) {
+	if !element.enabled { return }
+	// the distance between the value and either limit may not fit in T,
+	// so the value is compared against the last value that can be stepped
+	// from instead. if that overflows, no value can be stepped from.
+	value := element.value
+	highest := element.max - element.step
+	lowest  := element.min + element.step
+	for ; steps > 0; steps -- {
+		if highest > element.max || value > highest {
+			value = element.max
+			break
+		}
+		value += element.step
+	}
+	for ; steps < 0; steps ++ {
+		if lowest < element.min || value < lowest {
+			value = element.min
+			break
+		}
+		value -= element.step
+	}
+	element.change(value)
+	element.updateText()
+}
+
+func (element *SpinBox[T]) change (value T) {
+	value = element.clamp(value)
+	if value == element.value { return }
+	element.value = value
+	element.updateButtons()
+	if element.onChange != nil {
+		element.onChange()
+	}
+}
+
+func (element *SpinBox[T]) clamp (value T) T {
+	if value < element.min { value = element.min }
+	if value > element.max { value = element.max }
+	return value
+}
+
+func (element *SpinBox[T]) parse (text string) (value T, ok bool) {
+	switch {
+	case isFloat[T]():
+		parsed, err := strconv.ParseFloat(text, 64)
+		if err != nil { return }
+		if parsed < float64(element.min) { return element.min, true }
+		if parsed > float64(element.max) { return element.max, true }
+		return T(parsed), true
+	case isSigned[T]():
+		parsed, err := strconv.ParseInt(text, 10, 64)
+		if err != nil { return }
+		if parsed < int64(element.min) { return element.min, true }
+		if parsed > int64(element.max) { return element.max, true }
+		return T(parsed), true
+	default:
+		parsed, err := strconv.ParseUint(text, 10, 64)
+		if err != nil { return }
+		if parsed < uint64(element.min) { return element.min, true }
+		if parsed > uint64(element.max) { return element.max, true }
+		return T(parsed), true
+	}
+}
+
+func (element *SpinBox[T]) text () string {
+	switch {
+	case element.format != nil:
+		return element.format(element.value)
+	case isFloat[T]():
+		return strconv.FormatFloat (
+			float64(element.value), 'f',
+			element.precision, 64)
+	case isSigned[T]():
+		return strconv.FormatInt(int64(element.value), 10)
+	default:
+		return strconv.FormatUint(uint64(element.value), 10)
+	}
+}
+
+func (element *SpinBox[T]) updateText () {
+	element.formatting = true
+	element.input.SetValue(element.text())
+	element.formatting = false
+	element.updateButtons()
+}
+
+func (element *SpinBox[T]) updateButtons () {
+	element.decrement.SetEnabled(element.enabled && element.value > element.min)
+	element.increment.SetEnabled(element.enabled && element.value < element.max)
+}
+
+func (element *SpinBox[T]) updateMinimumSize () {
+	inputWidth,     inputHeight     := element.entity.ChildMinimumSize(0)
+	decrementWidth, decrementHeight := element.entity.ChildMinimumSize(1)
+	incrementWidth, incrementHeight := element.entity.ChildMinimumSize(2)
+
+	height := inputHeight
+	if decrementHeight > height { height = decrementHeight }
+	if incrementHeight > height { height = incrementHeight }
+	element.entity.SetMinimumSize (
+		inputWidth + decrementWidth + incrementWidth,
+		height)
+}
+
+func isFloat[T Numeric] () bool {
+	var half T = 1
+	half /= 2
+	return half != 0
+}
+
+func isSigned[T Numeric] () bool {
+	var negative T = 0
+	negative --
+	return negative < 0
+}
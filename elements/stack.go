@@ -0,0 +1,235 @@
+package elements
+
+import "image"
+import "tomo"
+import "art"
+import "art/shatter"
+
+var stackCase = tomo.C("tomo", "stack")
+
+type stackLayer struct {
+	element    tomo.Element
+	horizontal Align
+	vertical   Align
+}
+
+// Stack is a container that layers its children on top of each other, such as
+// a badge over an icon or a loading indicator over some content. Children added
+// later are drawn on top of, and receive mouse events before, children added
+// earlier. A Stack can also show only one child at a time, which makes it
+// useful for switching between pages.
+//
+// Layers are opaque. Children that draw their parent's background, such as
+// labels, fill it in with the stack's background rather than the layers below
+// them, so anything underneath a layer is hidden within its bounds. Overlays
+// should be aligned with SetAlign so that they only take up as much space as
+// they need.
+type Stack struct {
+	entity  tomo.Entity
+	padding bool
+
+	layers  []stackLayer
+	visible tomo.Element
+}
+
+// NewStack creates a new stack containing the specified children, from bottom
+// to top. Margin has no effect on stacks.
+func NewStack (space Space, children ...tomo.Element) (element *Stack) {
+	element = &Stack { padding: space.Includes(SpacePadding) }
+	element.entity = tomo.GetBackend().NewEntity(element)
+	element.Adopt(children...)
+	return
+}
+
+// Entity returns this element's entity.
+func (element *Stack) Entity () tomo.Entity {
+	return element.entity
+}
+
+// Draw causes the element to draw to the specified destination canvas.
+func (element *Stack) Draw (destination art.Canvas) {
+	rocks := make([]image.Rectangle, element.entity.CountChildren())
+	for index := 0; index < element.entity.CountChildren(); index ++ {
+		rocks[index] = element.entity.Child(index).Entity().Bounds()
+	}
+
+	tiles := shatter.Shatter(element.entity.Bounds(), rocks...)
+	for _, tile := range tiles {
+		element.entity.DrawBackground(art.Cut(destination, tile))
+	}
+}
+
+// Layout causes this element to perform a layout operation.
+func (element *Stack) Layout () {
+	bounds := element.entity.Bounds()
+	if element.padding {
+		padding := element.entity.Theme().Padding(tomo.PatternBackground, stackCase)
+		bounds = padding.Apply(bounds)
+	}
+
+	for _, layer := range element.layers {
+		index := element.entity.IndexOf(layer.element)
+		if index < 0 { continue }
+		area := bounds
+		width, height := element.entity.ChildMinimumSize(index)
+		area.Min.X, area.Max.X = alignSpan (
+			layer.horizontal, area.Min.X, area.Max.X, width)
+		area.Min.Y, area.Max.Y = alignSpan (
+			layer.vertical, area.Min.Y, area.Max.Y, height)
+		element.entity.PlaceChild(index, area)
+	}
+}
+
+// ChildrenOverlap returns whether the stack's children might overlap each
+// other, which is the case unless it is only showing one of them.
+func (element *Stack) ChildrenOverlap () bool {
+	return element.visible == nil
+}
+
+// DrawBackground draws this element's background pattern to the specified
+// destination canvas.
+func (element *Stack) DrawBackground (destination art.Canvas) {
+	element.entity.DrawBackground(destination)
+}
+
+// Adopt adds one or more elements to the top of the stack.
+func (element *Stack) Adopt (children ...tomo.Element) {
+	for _, child := range children {
+		element.layers = append(element.layers, stackLayer { element: child })
+		if element.visible == nil {
+			element.entity.Adopt(child)
+		}
+	}
+	element.changed()
+}
+
+// Disown removes one or more elements from the stack. If the visible child is
+// removed, the one below it becomes visible instead.
+func (element *Stack) Disown (children ...tomo.Element) {
+	for _, child := range children {
+		index := element.layerIndex(child)
+		if index < 0 { continue }
+		if childIndex := element.entity.IndexOf(child); childIndex >= 0 {
+			element.entity.Disown(childIndex)
+		}
+		element.layers = append(element.layers[:index], element.layers[index + 1:]...)
+
+		if child == element.visible {
+			element.visible = nil
+			if index > 0 { index -- }
+			if index < len(element.layers) {
+				element.visible = element.layers[index].element
+				element.entity.Adopt(element.visible)
+			}
+		}
+	}
+	element.changed()
+}
+
+// DisownAll removes all elements from the stack.
+func (element *Stack) DisownAll () {
+	for element.entity.CountChildren() > 0 {
+		element.entity.Disown(0)
+	}
+	element.layers  = nil
+	element.visible = nil
+	element.changed()
+}
+
+// Child returns the child at the specified index, counting from the bottom of
+// the stack.
+func (element *Stack) Child (index int) tomo.Element {
+	if index < 0 || index >= len(element.layers) { return nil }
+	return element.layers[index].element
+}
+
+// CountChildren returns the amount of children in this stack.
+func (element *Stack) CountChildren () int {
+	return len(element.layers)
+}
+
+// SetAlign sets how an element is positioned within the stack. By default,
+// elements fill the entire stack.
+func (element *Stack) SetAlign (child tomo.Element, horizontal, vertical Align) {
+	index := element.layerIndex(child)
+	if index < 0 { return }
+	element.layers[index].horizontal = horizontal
+	element.layers[index].vertical   = vertical
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+// Raise moves an element to the top of the stack.
+func (element *Stack) Raise (child tomo.Element) {
+	index := element.layerIndex(child)
+	if index < 0 || index == len(element.layers) - 1 { return }
+	layer := element.layers[index]
+	element.layers = append(element.layers[:index], element.layers[index + 1:]...)
+	element.layers = append(element.layers, layer)
+	element.adoptLayers()
+}
+
+// SetVisibleChild causes only the specified child to be shown, hiding the rest
+// of the stack. Passing nil shows every child again.
+func (element *Stack) SetVisibleChild (child tomo.Element) {
+	if child != nil && element.layerIndex(child) < 0 { return }
+	if element.visible == child { return }
+	element.visible = child
+	element.adoptLayers()
+}
+
+// VisibleChild returns the child that is being shown by itself, or nil if every
+// child is being shown.
+func (element *Stack) VisibleChild () tomo.Element {
+	return element.visible
+}
+
+func (element *Stack) HandleChildMinimumSizeChange (child tomo.Element) {
+	element.changed()
+}
+
+func (element *Stack) HandleThemeChange () {
+	element.changed()
+}
+
+// adoptLayers makes the children of the entity match the layers that should
+// be shown, in order from bottom to top.
+func (element *Stack) adoptLayers () {
+	for element.entity.CountChildren() > 0 {
+		element.entity.Disown(0)
+	}
+	for _, layer := range element.layers {
+		if element.visible == nil || element.visible == layer.element {
+			element.entity.Adopt(layer.element)
+		}
+	}
+	element.changed()
+}
+
+func (element *Stack) layerIndex (child tomo.Element) int {
+	for index, layer := range element.layers {
+		if layer.element == child { return index }
+	}
+	return -1
+}
+
+func (element *Stack) changed () {
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+func (element *Stack) updateMinimumSize () {
+	var width, height int
+	for index := 0; index < element.entity.CountChildren(); index ++ {
+		childWidth, childHeight := element.entity.ChildMinimumSize(index)
+		if childWidth  > width  { width  = childWidth  }
+		if childHeight > height { height = childHeight }
+	}
+	if element.padding {
+		padding := element.entity.Theme().Padding(tomo.PatternBackground, stackCase)
+		width  += padding.Horizontal()
+		height += padding.Vertical()
+	}
+	element.entity.SetMinimumSize(width, height)
+}
@@ -0,0 +1,214 @@
+package elements
+
+import "image"
+import "golang.org/x/image/font"
+import "tomo"
+import "tomo/input"
+import "art"
+import "tomo/textdraw"
+
+var statusBarCase        = tomo.C("tomo", "statusBar")
+var statusBarSegmentCase = tomo.C("tomo", "statusBar", "segment")
+var statusBarGripCase    = tomo.C("tomo", "statusBar", "grip")
+
+type statusBarSegment struct {
+	drawer textdraw.Drawer
+	text   string
+	expand bool
+}
+
+// StatusBar is a horizontal strip divided into segments of text, typically
+// placed at the bottom of a window. It has a grip in its corner that can be
+// dragged to resize the window.
+type StatusBar struct {
+	entity   tomo.Entity
+	segments []*statusBarSegment
+	grip     bool
+}
+
+// NewStatusBar creates a new status bar with no segments.
+func NewStatusBar () (element *StatusBar) {
+	element = &StatusBar { grip: true }
+	element.entity = tomo.GetBackend().NewEntity(element)
+	element.updateMinimumSize()
+	return
+}
+
+// Entity returns this element's entity.
+func (element *StatusBar) Entity () tomo.Entity {
+	return element.entity
+}
+
+// Draw causes the element to draw to the specified destination canvas.
+func (element *StatusBar) Draw (destination art.Canvas) {
+	theme := element.entity.Theme()
+	theme.Pattern(tomo.PatternRaised, tomo.State { }, statusBarCase).
+		Draw(destination, element.entity.Bounds())
+
+	state      := tomo.State { }
+	pattern    := theme.Pattern(tomo.PatternSunken, state, statusBarSegmentCase)
+	padding    := theme.Padding(tomo.PatternSunken, statusBarSegmentCase)
+	foreground := theme.Color(tomo.ColorForeground, state, statusBarSegmentCase)
+	for index, segment := range element.segments {
+		bounds := element.segmentBounds(index)
+		pattern.Draw(destination, bounds)
+		inner := padding.Apply(bounds)
+		textBounds := segment.drawer.LayoutBounds()
+		offset := image.Pt (
+			inner.Min.X,
+			inner.Min.Y + (inner.Dy() - textBounds.Dy()) / 2).Sub(textBounds.Min)
+		segment.drawer.Draw(art.Cut(destination, inner), foreground, offset)
+	}
+
+	if element.grip {
+		theme.Pattern(tomo.PatternHandle, state, statusBarGripCase).
+			Draw(destination, element.gripBounds())
+	}
+}
+
+// AddSegment adds a segment to the end of the status bar. If expand is true,
+// the segment is stretched to take up any space left over.
+func (element *StatusBar) AddSegment (text string, expand bool) {
+	segment := &statusBarSegment { expand: expand, text: text }
+	segment.drawer.SetFace(element.face())
+	segment.drawer.SetText([]rune(text))
+	element.segments = append(element.segments, segment)
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+}
+
+// CountSegments returns the amount of segments in the status bar.
+func (element *StatusBar) CountSegments () int {
+	return len(element.segments)
+}
+
+// SegmentText returns the text of the segment at the specified index.
+func (element *StatusBar) SegmentText (index int) string {
+	if index < 0 || index >= len(element.segments) { return "" }
+	return element.segments[index].text
+}
+
+// SetSegmentText sets the text of the segment at the specified index.
+func (element *StatusBar) SetSegmentText (index int, text string) {
+	if index < 0 || index >= len(element.segments) { return }
+	segment := element.segments[index]
+	if segment.text == text { return }
+	segment.text = text
+	segment.drawer.SetText([]rune(text))
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+}
+
+// SetGrip sets whether the status bar has a grip that can be dragged to resize
+// the window. It has one by default.
+func (element *StatusBar) SetGrip (grip bool) {
+	if element.grip == grip { return }
+	element.grip = grip
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+}
+
+func (element *StatusBar) HandleMouseDown (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) {
+	if !element.grip || button != input.ButtonLeft { return }
+	if !position.In(element.gripBounds()) { return }
+	window := element.entity.Window()
+	if window == nil { return }
+	window.BeginResize()
+}
+
+func (element *StatusBar) HandleMouseUp (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) { }
+
+func (element *StatusBar) HandleThemeChange () {
+	face := element.face()
+	for _, segment := range element.segments {
+		segment.drawer.SetFace(face)
+	}
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+}
+
+func (element *StatusBar) face () font.Face {
+	return element.entity.Theme().FontFace (
+		tomo.FontStyleRegular,
+		tomo.FontSizeNormal,
+		statusBarSegmentCase)
+}
+
+func (element *StatusBar) inner () image.Rectangle {
+	padding := element.entity.Theme().Padding(tomo.PatternRaised, statusBarCase)
+	return padding.Apply(element.entity.Bounds())
+}
+
+func (element *StatusBar) gripBounds () image.Rectangle {
+	bounds := element.entity.Bounds()
+	size := element.inner().Dy()
+	return image.Rect (
+		bounds.Max.X - size, bounds.Max.Y - size,
+		bounds.Max.X,        bounds.Max.Y)
+}
+
+func (element *StatusBar) segmentWidth (index int) int {
+	padding := element.entity.Theme().Padding(tomo.PatternSunken, statusBarSegmentCase)
+	return element.segments[index].drawer.LayoutBounds().Dx() + padding.Horizontal()
+}
+
+func (element *StatusBar) segmentBounds (index int) image.Rectangle {
+	margin := element.entity.Theme().Margin(tomo.PatternSunken, statusBarSegmentCase)
+	inner  := element.inner()
+	if element.grip {
+		inner.Max.X -= inner.Dy() + margin.X
+	}
+
+	// split the space left over between expanding segments
+	free := inner.Dx() - margin.X * (len(element.segments) - 1)
+	expanding := 0
+	for current, segment := range element.segments {
+		if segment.expand {
+			expanding ++
+		} else {
+			free -= element.segmentWidth(current)
+		}
+	}
+	width := func (current int) int {
+		if !element.segments[current].expand { return element.segmentWidth(current) }
+		minimum := element.segmentWidth(current)
+		share := free / expanding
+		if share < minimum { return minimum }
+		return share
+	}
+
+	x := inner.Min.X
+	for current := 0; current < index; current ++ {
+		x += width(current) + margin.X
+	}
+	return image.Rect(x, inner.Min.Y, x + width(index), inner.Max.Y)
+}
+
+func (element *StatusBar) updateMinimumSize () {
+	theme   := element.entity.Theme()
+	padding := theme.Padding(tomo.PatternRaised, statusBarCase)
+	segmentPadding := theme.Padding(tomo.PatternSunken, statusBarSegmentCase)
+	margin  := theme.Margin(tomo.PatternSunken, statusBarSegmentCase)
+
+	height := element.face().Metrics().Height.Ceil() + segmentPadding.Vertical()
+	width  := 0
+	for index := range element.segments {
+		if index > 0 { width += margin.X }
+		width += element.segmentWidth(index)
+	}
+	if element.grip {
+		if width > 0 { width += margin.X }
+		width += height
+	}
+	element.entity.SetMinimumSize (
+		width  + padding.Horizontal(),
+		height + padding.Vertical())
+}
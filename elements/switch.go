@@ -17,7 +17,8 @@ type Switch struct {
 	enabled bool
 	pressed bool
 	checked bool
-	text    string
+
+	label labelText
 	
 	onToggle func ()
 }
@@ -26,14 +27,13 @@ type Switch struct {
 func NewSwitch (text string, on bool) (element *Switch) {
 	element = &Switch {
 		checked: on,
-		text:    text,
 		enabled: true,
 	}
 	element.entity = tomo.GetBackend().NewEntity(element)
 	element.drawer.SetFace (element.entity.Theme().FontFace (
 		tomo.FontStyleRegular,
 		tomo.FontSizeNormal, switchCase))
-	element.drawer.SetText([]rune(text))
+	element.SetText(text)
 	element.updateMinimumSize()
 	return
 }
@@ -91,6 +91,16 @@ func (element *Switch) Draw (destination art.Canvas) {
 
 	foreground := element.entity.Theme().Color(tomo.ColorForeground, state, switchCase)
 	element.drawer.Draw(destination, foreground, offset)
+	element.label.underline(element.drawer, destination, foreground, offset)
+}
+
+func (element *Switch) Mnemonic () rune {
+	return element.label.mnemonic
+}
+
+func (element *Switch) HandleMnemonic () {
+	element.Focus()
+	element.toggle()
 }
 
 func (element *Switch) HandleFocusChange () {
@@ -116,14 +126,9 @@ func (element *Switch) HandleMouseUp (
 	if button != input.ButtonLeft || !element.pressed { return }
 
 	element.pressed = false
-	within := position.In(element.entity.Bounds())
-	if within {
-		element.checked = !element.checked
-	}
-	
 	element.entity.Invalidate()
-	if within && element.onToggle != nil {
-		element.onToggle()
+	if position.In(element.entity.Bounds()) {
+		element.toggle()
 	}
 }
 
@@ -137,11 +142,15 @@ func (element *Switch) HandleKeyDown (key input.Key, modifiers input.Modifiers)
 func (element *Switch) HandleKeyUp (key input.Key, modifiers input.Modifiers) {
 	if key == input.KeyEnter && element.pressed {
 		element.pressed = false
-		element.checked = !element.checked
-		element.entity.Invalidate()
-		if element.onToggle != nil {
-			element.onToggle()
-		}
+		element.toggle()
+	}
+}
+
+func (element *Switch) toggle () {
+	element.checked = !element.checked
+	element.entity.Invalidate()
+	if element.onToggle != nil {
+		element.onToggle()
 	}
 }
 
@@ -172,12 +181,22 @@ func (element *Switch) SetEnabled (enabled bool) {
 	element.entity.Invalidate()
 }
 
-// SetText sets the checkbox's label text.
+// SetText sets the switch's label text.
 func (element *Switch) SetText (text string) {
-	if element.text == text { return }
+	element.setText(text, false)
+}
+
+// SetTextWithMnemonic is like SetText, but an underscore marks the character
+// after it as the mnemonic, which can be pressed along with Alt to activate the
+// switch. Use two underscores to display a literal underscore.
+func (element *Switch) SetTextWithMnemonic (text string) {
+	element.setText(text, true)
+}
 
-	element.text = text
-	element.drawer.SetText([]rune(text))
+func (element *Switch) setText (text string, parse bool) {
+	display, changed := element.label.set(text, parse)
+	if !changed { return }
+	element.drawer.SetText(display)
 	element.updateMinimumSize()
 	element.entity.Invalidate()
 }
@@ -194,7 +213,7 @@ func (element *Switch) updateMinimumSize () {
 	textBounds := element.drawer.LayoutBounds()
 	lineHeight := element.drawer.LineHeight().Round()
 	
-	if element.text == "" {
+	if element.label.text == "" {
 		element.entity.SetMinimumSize(lineHeight * 2, lineHeight)
 	} else {
 		element.entity.SetMinimumSize (
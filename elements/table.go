@@ -0,0 +1,969 @@
+package elements
+
+import "time"
+import "sort"
+import "image"
+import "strconv"
+import "golang.org/x/image/font"
+import "tomo"
+import "tomo/menu"
+import "tomo/input"
+import "art"
+import "tomo/textdraw"
+
+var tableCase     = tomo.C("tomo", "table")
+var tableHeadCase = tomo.C("tomo", "table", "head")
+var tableCellCase = tomo.C("tomo", "table", "cell")
+
+// TableModel provides the data displayed by a Table. Rows and columns are
+// identified by their index, starting from zero.
+type TableModel interface {
+	// Columns returns the amount of columns in the table.
+	Columns () int
+
+	// ColumnName returns the text displayed in the header of the specified
+	// column.
+	ColumnName (column int) string
+
+	// Rows returns the amount of rows in the table.
+	Rows () int
+
+	// Cell returns the text displayed in the cell at the specified row and
+	// column.
+	Cell (row, column int) string
+}
+
+// TableSorter can be implemented by a TableModel to control how its rows are
+// sorted. If a model does not implement it, cells that contain numbers are
+// compared numerically and all others are compared as text.
+type TableSorter interface {
+	TableModel
+
+	// Less returns whether row a should come before row b when sorting by
+	// the specified column in ascending order.
+	Less (column, a, b int) bool
+}
+
+// TableGrouper can be implemented by a TableModel to split its rows into groups
+// that stay in order no matter which column the table is sorted by, or in which
+// direction. Rows are only sorted against other rows in the same group.
+type TableGrouper interface {
+	TableModel
+
+	// Group returns the group that the specified row belongs to. Groups
+	// with lower numbers are shown first.
+	Group (row int) int
+}
+
+type tableColumn struct {
+	drawer  textdraw.Drawer
+	width   int
+	natural int
+	hidden  bool
+}
+
+// Table displays data from a TableModel in rows and columns. Clicking on the
+// header of a column sorts the table by that column, dragging the edge of a
+// header resizes its column, and right-clicking the header shows a menu that
+// can hide columns. It is meant to be placed inside of a Scroll.
+type Table struct {
+	entity tomo.Entity
+	model  TableModel
+	drawer textdraw.Drawer
+
+	columns    []tableColumn
+	textWidths map[string] int
+	order      []int
+	rank       []int
+	selected   []bool
+
+	sortColumn int
+	descending bool
+
+	selector     selector
+	enabled      bool
+	cursorRow    int
+	cursorColumn int
+	anchor       int
+
+	pressedHead  int
+	resizing     int
+	resizeOffset int
+
+	scroll              image.Point
+	forcedMinimumWidth  int
+	forcedMinimumHeight int
+
+	lastClick   time.Time
+	lastClicked int
+
+	onActivate           func (row int)
+	onScrollBoundsChange func ()
+}
+
+// NewTable creates a new table displaying data from the specified model.
+func NewTable (model TableModel) (element *Table) {
+	element = &Table { enabled: true }
+	element.entity = tomo.GetBackend().NewEntity(element)
+	element.selector.items = element
+	element.drawer.SetFace(element.face())
+	element.SetModel(model)
+	return
+}
+
+// Entity returns this element's entity.
+func (element *Table) Entity () tomo.Entity {
+	return element.entity
+}
+
+// Draw causes the element to draw to the specified destination canvas.
+func (element *Table) Draw (destination art.Canvas) {
+	element.entity.Theme().Pattern(tomo.PatternSunken, element.state(), tableCase).
+		Draw(destination, element.entity.Bounds())
+
+	head := element.headBounds()
+	headCanvas := art.Cut(destination, head)
+	for column := range element.columns {
+		if element.columns[column].hidden { continue }
+		left, right := element.columnSpan(column)
+		if right < head.Min.X || left > head.Max.X { continue }
+		element.drawHead(headCanvas, column, image.Rect(left, head.Min.Y, right, head.Max.Y))
+	}
+
+	if len(element.order) == 0 { return }
+	body   := element.body()
+	canvas := art.Cut(destination, body)
+	height := element.rowHeight()
+	first  := element.scroll.Y / height
+	last   := (element.scroll.Y + body.Dy()) / height
+	for index := first; index <= last && index < len(element.order); index ++ {
+		element.drawRow(canvas, index)
+	}
+}
+
+func (element *Table) drawHead (destination art.Canvas, column int, bounds image.Rectangle) {
+	theme := element.entity.Theme()
+	state := tomo.State {
+		Disabled: !element.enabled,
+		Pressed:  element.pressedHead == column,
+		On:       element.sortColumn == column,
+	}
+	theme.Pattern(tomo.PatternTableHead, state, tableHeadCase).
+		Draw(destination, bounds)
+
+	foreground := theme.Color(tomo.ColorForeground, state, tableHeadCase)
+	padding    := theme.Padding(tomo.PatternTableHead, tableHeadCase)
+	inner      := padding.Apply(bounds)
+	cut        := art.Cut(destination, inner)
+
+	if icon := element.sortIcon(column); icon != nil {
+		iconBounds := icon.Bounds()
+		icon.Draw(cut, foreground, image.Pt (
+			inner.Max.X - iconBounds.Dx(),
+			inner.Min.Y + (inner.Dy() - iconBounds.Dy()) / 2))
+	}
+
+	drawer := &element.columns[column].drawer
+	textBounds := drawer.LayoutBounds()
+	offset := image.Pt (
+		inner.Min.X,
+		inner.Min.Y + (inner.Dy() - textBounds.Dy()) / 2).Sub(textBounds.Min)
+	drawer.Draw(cut, foreground, offset)
+}
+
+func (element *Table) drawRow (destination art.Canvas, index int) {
+	theme   := element.entity.Theme()
+	padding := theme.Padding(tomo.PatternTableCell, tableCellCase)
+	row     := element.order[index]
+	bounds  := destination.Bounds()
+	top     := element.rowTop(index)
+	height  := element.rowHeight()
+
+	for column := range element.columns {
+		if element.columns[column].hidden { continue }
+		left, right := element.columnSpan(column)
+		if right < bounds.Min.X || left > bounds.Max.X { continue }
+
+		cell  := image.Rect(left, top, right, top + height)
+		state := tomo.State {
+			Disabled: !element.enabled,
+			On:       element.selected[row],
+			Focused:
+				element.entity.Focused() &&
+				row    == element.cursorRow &&
+				column == element.cursorColumn,
+		}
+		theme.Pattern(tomo.PatternTableCell, state, tableCellCase).
+			Draw(destination, cell)
+
+		foreground := theme.Color(tomo.ColorForeground, state, tableCellCase)
+		inner := padding.Apply(cell)
+		element.drawer.SetText([]rune(element.model.Cell(row, column)))
+		textBounds := element.drawer.LayoutBounds()
+		offset := image.Pt (
+			inner.Min.X,
+			inner.Min.Y + (inner.Dy() - textBounds.Dy()) / 2).Sub(textBounds.Min)
+		element.drawer.Draw(art.Cut(destination, inner), foreground, offset)
+	}
+}
+
+// Layout causes this element to perform a layout operation.
+func (element *Table) Layout () {
+	element.clampScroll()
+	element.entity.NotifyScrollBoundsChange()
+	if element.onScrollBoundsChange != nil {
+		element.onScrollBoundsChange()
+	}
+}
+
+// SetModel sets the model that the table gets its data from. The table is
+// unsorted, the selection is cleared, and all columns are shown and sized to
+// fit their contents.
+func (element *Table) SetModel (model TableModel) {
+	element.model        = model
+	element.columns      = nil
+	element.selected     = nil
+	element.sortColumn   = -1
+	element.descending   = false
+	element.cursorRow    = -1
+	element.cursorColumn = 0
+	element.anchor       = -1
+	element.pressedHead  = -1
+	element.resizing     = -1
+	element.lastClicked  = -1
+	element.scroll       = image.Point { }
+	element.Refresh()
+}
+
+// Model returns the model that the table gets its data from.
+func (element *Table) Model () TableModel {
+	return element.model
+}
+
+// Refresh reloads the table's data from the model. This must be called whenever
+// the data in the model changes. Rows that are still present stay selected, and
+// the table is sorted again.
+func (element *Table) Refresh () {
+	columns, rows := 0, 0
+	if element.model != nil {
+		columns = element.model.Columns()
+		rows    = element.model.Rows()
+	}
+
+	// keep the state of columns that are still present
+	if columns < len(element.columns) {
+		element.columns = element.columns[:columns]
+	}
+	for len(element.columns) < columns {
+		element.columns = append(element.columns, tableColumn { })
+	}
+	headFace := element.headFace()
+	for column := range element.columns {
+		drawer := &element.columns[column].drawer
+		drawer.SetFace(headFace)
+		drawer.SetText([]rune(element.model.ColumnName(column)))
+	}
+	if element.sortColumn >= columns { element.sortColumn = -1 }
+	if element.cursorColumn >= columns { element.cursorColumn = 0 }
+
+	selectionChanged := false
+	if rows < len(element.selected) {
+		for _, selected := range element.selected[rows:] {
+			if selected { selectionChanged = true }
+		}
+		element.selected = element.selected[:rows]
+	}
+	for len(element.selected) < rows {
+		element.selected = append(element.selected, false)
+	}
+	if element.cursorRow   >= rows { element.cursorRow   = -1 }
+	if element.anchor      >= rows { element.anchor      = -1 }
+	if element.lastClicked >= rows { element.lastClicked = -1 }
+
+	element.measure()
+	element.reorder()
+	element.changed()
+	if selectionChanged && element.selector.onSelectionChange != nil {
+		element.selector.onSelectionChange()
+	}
+}
+
+// SortBy sorts the table by the specified column. Passing -1 shows the rows in
+// the order given by the model.
+func (element *Table) SortBy (column int, descending bool) {
+	if column >= len(element.columns) { return }
+	if column < 0 { column, descending = -1, false }
+	element.sortColumn = column
+	element.descending = descending
+	element.reorder()
+	element.entity.Invalidate()
+	element.scrollToCursor()
+}
+
+// SortColumn returns the column that the table is sorted by, and whether it is
+// sorted in descending order. If the table is not sorted, the column is -1.
+func (element *Table) SortColumn () (column int, descending bool) {
+	return element.sortColumn, element.descending
+}
+
+// ColumnWidth returns the width of the specified column.
+func (element *Table) ColumnWidth (column int) int {
+	if column < 0 || column >= len(element.columns) { return 0 }
+	if element.columns[column].width > 0 {
+		return element.columns[column].width
+	}
+	return element.columns[column].natural
+}
+
+// SetColumnWidth sets the width of the specified column. If the width is zero,
+// the column is sized to fit its contents.
+func (element *Table) SetColumnWidth (column, width int) {
+	if column < 0 || column >= len(element.columns) { return }
+	if width != 0 {
+		minimum := element.minimumColumnWidth()
+		if width < minimum { width = minimum }
+	}
+	if element.columns[column].width == width { return }
+	element.columns[column].width = width
+	element.changed()
+}
+
+// ColumnHidden returns whether the specified column is hidden.
+func (element *Table) ColumnHidden (column int) bool {
+	if column < 0 || column >= len(element.columns) { return false }
+	return element.columns[column].hidden
+}
+
+// SetColumnHidden sets whether the specified column is hidden. Columns can also
+// be hidden by the user through the context menu of the header.
+func (element *Table) SetColumnHidden (column int, hidden bool) {
+	if column < 0 || column >= len(element.columns) { return }
+	if element.columns[column].hidden == hidden { return }
+	element.columns[column].hidden = hidden
+	if hidden && column == element.cursorColumn {
+		element.moveColumn(1)
+		if element.cursorColumn == column { element.moveColumn(-1) }
+	}
+	element.changed()
+}
+
+// SelectionMode returns how many rows can be selected at once.
+func (element *Table) SelectionMode () SelectionMode {
+	return element.selector.mode
+}
+
+// SetSelectionMode sets how many rows can be selected at once. By default, only
+// one row can be selected.
+func (element *Table) SetSelectionMode (mode SelectionMode) {
+	element.selector.setMode(mode)
+}
+
+// Selected returns the indices of the selected rows, in the order they are
+// displayed.
+func (element *Table) Selected () (rows []int) {
+	for _, row := range element.order {
+		if element.selected[row] { rows = append(rows, row) }
+	}
+	return
+}
+
+// Select selects the specified rows, deselecting all others. If only one row
+// can be selected at a time, only the first is used. Calling Select with no
+// rows clears the selection.
+func (element *Table) Select (rows ...int) {
+	if element.selector.mode == SelectionSingle && len(rows) > 1 {
+		rows = rows[:1]
+	}
+	wanted := make(map[int] bool)
+	for _, row := range rows {
+		if row < 0 || row >= len(element.selected) { continue }
+		wanted[row] = true
+		element.cursorRow = row
+		element.anchor    = row
+	}
+	element.selector.setSelection (func (index int) bool {
+		return wanted[element.order[index]]
+	})
+	element.scrollToCursor()
+}
+
+// Cursor returns the row and column of the cell that has the keyboard cursor.
+// If no cell has it, the row is -1.
+func (element *Table) Cursor () (row, column int) {
+	return element.cursorRow, element.cursorColumn
+}
+
+// OnSelectionChange sets a function to be called when rows are selected or
+// deselected.
+func (element *Table) OnSelectionChange (callback func ()) {
+	element.selector.onSelectionChange = callback
+}
+
+// OnActivate sets a function to be called when a row is double-clicked, or when
+// Enter is pressed while it has the cursor.
+func (element *Table) OnActivate (callback func (row int)) {
+	element.onActivate = callback
+}
+
+// Focus gives this element input focus.
+func (element *Table) Focus () {
+	if !element.entity.Focused() { element.entity.Focus() }
+}
+
+// Enabled returns whether this table is enabled or not.
+func (element *Table) Enabled () bool {
+	return element.enabled
+}
+
+// SetEnabled sets whether this table can be interacted with or not.
+func (element *Table) SetEnabled (enabled bool) {
+	if element.enabled == enabled { return }
+	element.enabled = enabled
+	element.entity.Invalidate()
+}
+
+// ContextMenu returns a menu that can hide and show columns when the header is
+// right-clicked.
+func (element *Table) ContextMenu (position image.Point) menu.Menu {
+	if !element.enabled || !position.In(element.headBounds()) { return nil }
+	shown := 0
+	for _, column := range element.columns {
+		if !column.hidden { shown ++ }
+	}
+
+	items := make(menu.Menu, len(element.columns))
+	for column := range element.columns {
+		column := column
+		hidden := element.columns[column].hidden
+		items[column] = menu.Check (
+			element.model.ColumnName(column), !hidden,
+			func () { element.SetColumnHidden(column, !hidden) })
+		// at least one column must be left showing
+		items[column].Disabled = !hidden && shown == 1
+	}
+	return items
+}
+
+func (element *Table) HandleFocusChange () {
+	element.entity.Invalidate()
+}
+
+func (element *Table) HandleMouseDown (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) {
+	if !element.enabled { return }
+	element.Focus()
+	if button != input.ButtonLeft { return }
+
+	if position.In(element.headBounds()) {
+		if column := element.edgeAt(position.X); column >= 0 {
+			_, right := element.columnSpan(column)
+			element.resizing     = column
+			element.resizeOffset = right - position.X
+			return
+		}
+		element.pressedHead = element.columnAt(position.X)
+		element.entity.Invalidate()
+		return
+	}
+
+	index := element.rowAt(position)
+	if index < 0 {
+		if !modifiers.Control && !modifiers.Shift {
+			element.selector.selectNone()
+		}
+		return
+	}
+	if column := element.columnAt(position.X); column >= 0 {
+		element.cursorColumn = column
+	}
+	element.pick(index, modifiers, false)
+
+	row   := element.order[index]
+	delay := element.entity.Config().DoubleClickDelay()
+	if row == element.lastClicked && time.Since(element.lastClick) < delay {
+		element.lastClicked = -1
+		element.activate(row)
+	} else {
+		element.lastClicked = row
+		element.lastClick   = time.Now()
+	}
+}
+
+func (element *Table) HandleMouseUp (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) {
+	if button != input.ButtonLeft { return }
+	if element.resizing >= 0 {
+		element.resizing = -1
+		return
+	}
+	if element.pressedHead < 0 { return }
+
+	column := element.pressedHead
+	element.pressedHead = -1
+	element.entity.Invalidate()
+	if !position.In(element.headBounds()) { return }
+	if element.columnAt(position.X) != column { return }
+	if column == element.sortColumn {
+		element.SortBy(column, !element.descending)
+	} else {
+		element.SortBy(column, false)
+	}
+}
+
+func (element *Table) HandleMotion (position image.Point) {
+	if element.resizing < 0 { return }
+	left, _ := element.columnSpan(element.resizing)
+	element.SetColumnWidth (
+		element.resizing,
+		position.X + element.resizeOffset - left)
+}
+
+func (element *Table) HandleKeyDown (key input.Key, modifiers input.Modifiers) {
+	if !element.enabled { return }
+	if key == 'a' && modifiers.Control {
+		element.selector.selectAll()
+		return
+	}
+
+	if len(element.order) == 0 { return }
+	index := -1
+	if element.cursorRow >= 0 { index = element.rank[element.cursorRow] }
+	last := len(element.order) - 1
+
+	switch key {
+	case input.KeyUp:
+		if index < 1 { index = 1 }
+		element.pick(index - 1, modifiers, true)
+	case input.KeyDown:
+		element.pick(index + 1, modifiers, true)
+	case input.KeyPageUp:
+		index -= element.pageRows()
+		if index < 0 { index = 0 }
+		element.pick(index, modifiers, true)
+	case input.KeyPageDown:
+		index += element.pageRows()
+		if index > last { index = last }
+		element.pick(index, modifiers, true)
+	case input.KeyHome:
+		element.pick(0, modifiers, true)
+	case input.KeyEnd:
+		element.pick(last, modifiers, true)
+	case input.KeyLeft:
+		element.moveColumn(-1)
+	case input.KeyRight:
+		element.moveColumn(1)
+	case ' ':
+		if index >= 0 { element.pick(index, modifiers, false) }
+	case input.KeyEnter:
+		if index >= 0 { element.activate(element.cursorRow) }
+	}
+}
+
+func (element *Table) HandleKeyUp (key input.Key, modifiers input.Modifiers) { }
+
+func (element *Table) HandleThemeChange () {
+	element.drawer.SetFace(element.face())
+	headFace := element.headFace()
+	for column := range element.columns {
+		element.columns[column].drawer.SetFace(headFace)
+	}
+	// the cached widths were measured with the old face
+	element.textWidths = nil
+	element.measure()
+	element.changed()
+}
+
+// Collapse forces a minimum width and height upon the table. If a zero value is
+// given for a dimension, its minimum will be determined by the table's content.
+// If the table's content goes beyond the forced size, it will need to be
+// accessed via scrolling.
+func (element *Table) Collapse (width, height int) {
+	if
+		element.forcedMinimumWidth == width &&
+		element.forcedMinimumHeight == height {
+
+		return
+	}
+
+	element.forcedMinimumWidth  = width
+	element.forcedMinimumHeight = height
+	element.changed()
+}
+
+// ScrollContentBounds returns the full content size of the element.
+func (element *Table) ScrollContentBounds () image.Rectangle {
+	return image.Rect (
+		0, 0,
+		element.contentWidth(),
+		len(element.order) * element.rowHeight())
+}
+
+// ScrollViewportBounds returns the size and position of the element's
+// viewport relative to ScrollBounds.
+func (element *Table) ScrollViewportBounds () image.Rectangle {
+	bounds := element.body()
+	return bounds.Sub(bounds.Min).Add(element.scroll)
+}
+
+// ScrollTo scrolls the viewport to the specified point relative to
+// ScrollBounds.
+func (element *Table) ScrollTo (position image.Point) {
+	element.scroll = position
+	element.clampScroll()
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+// ScrollAxes returns the supported axes for scrolling.
+func (element *Table) ScrollAxes () (horizontal, vertical bool) {
+	return true, true
+}
+
+// OnScrollBoundsChange sets a function to be called when the element's viewport
+// bounds, content bounds, or scroll axes change.
+func (element *Table) OnScrollBoundsChange (callback func ()) {
+	element.onScrollBoundsChange = callback
+}
+
+// measure finds the width that each column needs to fit its contents.
+func (element *Table) measure () {
+	theme       := element.entity.Theme()
+	face        := element.face()
+	cellPadding := theme.Padding(tomo.PatternTableCell, tableCellCase).Horizontal()
+	headPadding := theme.Padding(tomo.PatternTableHead, tableHeadCase).Horizontal()
+	headMargin  := theme.Margin(tomo.PatternTableHead, tableHeadCase).X
+
+	// space is always left for the sort icon so that sorting doesn't
+	// change the layout
+	iconWidth := 0
+	if icon := theme.Icon(tomo.IconUpward, tomo.IconSizeSmall, tableHeadCase); icon != nil {
+		iconWidth = icon.Bounds().Dx() + headMargin
+	}
+
+	// measuring text is slow, so the width of each piece of text is kept
+	// from the last time the table was measured. text that is no longer in
+	// the table is dropped from the cache.
+	previous := element.textWidths
+	element.textWidths = make(map[string] int, len(previous))
+	rows := len(element.selected)
+	for column := range element.columns {
+		width := element.columns[column].drawer.LayoutBounds().Dx() +
+			iconWidth + headPadding
+		for row := 0; row < rows; row ++ {
+			text := element.model.Cell(row, column)
+			textWidth, ok := element.textWidths[text]
+			if !ok {
+				textWidth, ok = previous[text]
+				if !ok { textWidth = font.MeasureString(face, text).Ceil() }
+				element.textWidths[text] = textWidth
+			}
+			cellWidth := textWidth + cellPadding
+			if cellWidth > width { width = cellWidth }
+		}
+		element.columns[column].natural = width
+	}
+}
+
+// reorder puts the rows in order according to the sort column.
+func (element *Table) reorder () {
+	rows := len(element.selected)
+	element.order = make([]int, rows)
+	element.rank  = make([]int, rows)
+	for row := range element.order {
+		element.order[row] = row
+	}
+
+	if element.sortColumn >= 0 {
+		column := element.sortColumn
+		sort.SliceStable(element.order, func (i, j int) bool {
+			a, b := element.order[i], element.order[j]
+			if grouper, ok := element.model.(TableGrouper); ok {
+				groupA, groupB := grouper.Group(a), grouper.Group(b)
+				if groupA != groupB { return groupA < groupB }
+			}
+			if element.descending { a, b = b, a }
+			return element.less(column, a, b)
+		})
+	}
+
+	for index, row := range element.order {
+		element.rank[row] = index
+	}
+}
+
+func (element *Table) less (column, a, b int) bool {
+	if sorter, ok := element.model.(TableSorter); ok {
+		return sorter.Less(column, a, b)
+	}
+	cellA := element.model.Cell(a, column)
+	cellB := element.model.Cell(b, column)
+	numberA, errA := strconv.ParseFloat(cellA, 64)
+	numberB, errB := strconv.ParseFloat(cellB, 64)
+	if errA == nil && errB == nil {
+		return numberA < numberB
+	}
+	return cellA < cellB
+}
+
+// pick moves the cursor to the row at the specified index, and changes the
+// selection depending on which modifier keys are held down.
+func (element *Table) pick (index int, modifiers input.Modifiers, keyboard bool) {
+	element.selector.pick(index, modifiers, keyboard)
+	element.entity.Invalidate()
+	element.scrollToCursor()
+}
+
+func (element *Table) itemCount () int {
+	return len(element.order)
+}
+
+func (element *Table) itemSelected (index int) bool {
+	return element.selected[element.order[index]]
+}
+
+func (element *Table) selectItem (index int, selected bool) (changed bool) {
+	row := element.order[index]
+	if element.selected[row] == selected { return false }
+	element.selected[row] = selected
+	element.entity.Invalidate()
+	return true
+}
+
+func (element *Table) cursorIndex () int {
+	if element.cursorRow < 0 { return -1 }
+	return element.rank[element.cursorRow]
+}
+
+func (element *Table) setCursorIndex (index int) {
+	element.cursorRow = element.order[index]
+}
+
+func (element *Table) anchorIndex () int {
+	if element.anchor < 0 { return -1 }
+	return element.rank[element.anchor]
+}
+
+func (element *Table) setAnchorIndex (index int) {
+	element.anchor = element.order[index]
+}
+
+func (element *Table) activate (row int) {
+	if element.onActivate != nil {
+		element.onActivate(row)
+	}
+}
+
+// moveColumn moves the cursor to the next column that is shown in the specified
+// direction.
+func (element *Table) moveColumn (direction int) {
+	column := element.cursorColumn + direction
+	for column >= 0 && column < len(element.columns) {
+		if !element.columns[column].hidden {
+			element.cursorColumn = column
+			element.entity.Invalidate()
+			element.scrollToCursor()
+			return
+		}
+		column += direction
+	}
+}
+
+func (element *Table) scrollToCursor () {
+	body   := element.body()
+	scroll := element.scroll
+
+	if element.cursorRow >= 0 {
+		top    := element.rowTop(element.rank[element.cursorRow])
+		bottom := top + element.rowHeight()
+		if top < body.Min.Y {
+			scroll.Y -= body.Min.Y - top
+		} else if bottom > body.Max.Y {
+			scroll.Y += bottom - body.Max.Y
+		}
+	}
+
+	left, right := element.columnSpan(element.cursorColumn)
+	if right > body.Max.X {
+		scroll.X += right - body.Max.X
+		left     -= right - body.Max.X
+	}
+	if left < body.Min.X {
+		scroll.X -= body.Min.X - left
+	}
+
+	if scroll != element.scroll {
+		element.ScrollTo(scroll)
+	}
+}
+
+func (element *Table) clampScroll () {
+	content  := element.ScrollContentBounds()
+	viewport := element.body()
+	maxX := content.Dx() - viewport.Dx()
+	maxY := content.Dy() - viewport.Dy()
+	if element.scroll.X > maxX { element.scroll.X = maxX }
+	if element.scroll.Y > maxY { element.scroll.Y = maxY }
+	if element.scroll.X < 0    { element.scroll.X = 0    }
+	if element.scroll.Y < 0    { element.scroll.Y = 0    }
+}
+
+// columnSpan returns the horizontal extent of a column on screen.
+func (element *Table) columnSpan (column int) (left, right int) {
+	left = element.inner().Min.X - element.scroll.X
+	for current := 0; current < column && current < len(element.columns); current ++ {
+		if element.columns[current].hidden { continue }
+		left += element.ColumnWidth(current)
+	}
+	right = left
+	if column >= 0 && column < len(element.columns) && !element.columns[column].hidden {
+		right += element.ColumnWidth(column)
+	}
+	return
+}
+
+func (element *Table) columnAt (x int) int {
+	for column := range element.columns {
+		if element.columns[column].hidden { continue }
+		left, right := element.columnSpan(column)
+		if x >= left && x < right { return column }
+	}
+	return -1
+}
+
+// edgeAt returns the column whose right edge is close enough to x to be
+// dragged.
+func (element *Table) edgeAt (x int) int {
+	padding := element.entity.Theme().Padding(tomo.PatternTableHead, tableHeadCase)
+	grab := padding[1]
+	if grab < 2 { grab = 2 }
+	for column := range element.columns {
+		if element.columns[column].hidden { continue }
+		_, right := element.columnSpan(column)
+		if x >= right - grab && x <= right + grab { return column }
+	}
+	return -1
+}
+
+func (element *Table) rowAt (position image.Point) int {
+	body := element.body()
+	if !position.In(body) { return -1 }
+	if position.X >= body.Min.X + element.contentWidth() - element.scroll.X {
+		return -1
+	}
+	index := (position.Y - body.Min.Y + element.scroll.Y) / element.rowHeight()
+	if index >= len(element.order) { return -1 }
+	return index
+}
+
+func (element *Table) rowTop (index int) int {
+	return element.body().Min.Y + index * element.rowHeight() - element.scroll.Y
+}
+
+func (element *Table) rowHeight () int {
+	padding := element.entity.Theme().Padding(tomo.PatternTableCell, tableCellCase)
+	return element.face().Metrics().Height.Ceil() + padding.Vertical()
+}
+
+func (element *Table) headHeight () int {
+	theme   := element.entity.Theme()
+	padding := theme.Padding(tomo.PatternTableHead, tableHeadCase)
+	height  := element.headFace().Metrics().Height.Ceil()
+	if icon := theme.Icon(tomo.IconUpward, tomo.IconSizeSmall, tableHeadCase); icon != nil {
+		if icon.Bounds().Dy() > height { height = icon.Bounds().Dy() }
+	}
+	return height + padding.Vertical()
+}
+
+func (element *Table) sortIcon (column int) art.Icon {
+	if column != element.sortColumn { return nil }
+	id := tomo.IconUpward
+	if element.descending { id = tomo.IconExpand }
+	return element.entity.Theme().Icon(id, tomo.IconSizeSmall, tableHeadCase)
+}
+
+func (element *Table) minimumColumnWidth () int {
+	padding := element.entity.Theme().Padding(tomo.PatternTableHead, tableHeadCase)
+	return padding.Horizontal() + element.headFace().Metrics().Height.Ceil()
+}
+
+func (element *Table) contentWidth () (width int) {
+	for column := range element.columns {
+		if element.columns[column].hidden { continue }
+		width += element.ColumnWidth(column)
+	}
+	return
+}
+
+func (element *Table) pageRows () int {
+	rows := element.body().Dy() / element.rowHeight()
+	if rows < 1 { rows = 1 }
+	return rows
+}
+
+func (element *Table) inner () image.Rectangle {
+	padding := element.entity.Theme().Padding(tomo.PatternSunken, tableCase)
+	return padding.Apply(element.entity.Bounds())
+}
+
+func (element *Table) headBounds () image.Rectangle {
+	bounds := element.inner()
+	bounds.Max.Y = bounds.Min.Y + element.headHeight()
+	return bounds
+}
+
+func (element *Table) body () image.Rectangle {
+	bounds := element.inner()
+	bounds.Min.Y += element.headHeight()
+	if bounds.Min.Y > bounds.Max.Y { bounds.Min.Y = bounds.Max.Y }
+	return bounds
+}
+
+func (element *Table) face () font.Face {
+	return element.entity.Theme().FontFace (
+		tomo.FontStyleRegular,
+		tomo.FontSizeNormal,
+		tableCellCase)
+}
+
+func (element *Table) headFace () font.Face {
+	return element.entity.Theme().FontFace (
+		tomo.FontStyleBold,
+		tomo.FontSizeNormal,
+		tableHeadCase)
+}
+
+func (element *Table) state () tomo.State {
+	return tomo.State {
+		Focused:  element.entity.Focused(),
+		Disabled: !element.enabled,
+	}
+}
+
+func (element *Table) changed () {
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+func (element *Table) updateMinimumSize () {
+	padding := element.entity.Theme().Padding(tomo.PatternSunken, tableCase)
+	width   := element.contentWidth() + padding.Horizontal()
+	height  :=
+		element.headHeight() +
+		len(element.order) * element.rowHeight() +
+		padding.Vertical()
+
+	if element.forcedMinimumWidth > 0 {
+		width = element.forcedMinimumWidth
+	}
+	if element.forcedMinimumHeight > 0 {
+		height = element.forcedMinimumHeight
+	}
+	element.entity.SetMinimumSize(width, height)
+}
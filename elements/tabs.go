@@ -0,0 +1,715 @@
+package elements
+
+import "image"
+import "tomo"
+import "tomo/input"
+import "art"
+import "art/shatter"
+import "tomo/textdraw"
+
+var tabsCase       = tomo.C("tomo", "tabs")
+var tabsTabCase    = tomo.C("tomo", "tabs", "tab")
+var tabsCloseCase  = tomo.C("tomo", "tabs", "close")
+var tabsScrollCase = tomo.C("tomo", "tabs", "scroll")
+
+type tabsTab struct {
+	page     tomo.Element
+	text     string
+	drawer   textdraw.Drawer
+	hasIcon  bool
+	iconId   tomo.Icon
+	closable bool
+	width    int
+}
+
+// Tabs is a container that holds several pages, only one of which is shown at a
+// time. A strip of tabs along the top allows the user to switch between them.
+// Tabs can be closed and dragged to reorder them, and the strip can be
+// scrolled when there are too many tabs to fit.
+type Tabs struct {
+	entity tomo.Entity
+
+	tabs     []*tabsTab
+	selected int
+	enabled  bool
+
+	strip       image.Rectangle
+	visible     image.Rectangle
+	backward    image.Rectangle
+	forward     image.Rectangle
+	pageArea    image.Rectangle
+	stripHeight int
+	scroll      int
+
+	pressed      int
+	closePressed int
+
+	onSelect   func ()
+	onTabClose func (page tomo.Element) bool
+}
+
+// NewTabs creates a new, empty tab container.
+func NewTabs () (element *Tabs) {
+	element = &Tabs {
+		selected:     -1,
+		pressed:      -1,
+		closePressed: -1,
+		enabled:      true,
+	}
+	element.entity = tomo.GetBackend().NewEntity(element)
+	element.updateMinimumSize()
+	return
+}
+
+// Entity returns this element's entity.
+func (element *Tabs) Entity () tomo.Entity {
+	return element.entity
+}
+
+// Draw causes the element to draw to the specified destination canvas.
+func (element *Tabs) Draw (destination art.Canvas) {
+	theme := element.entity.Theme()
+
+	// background around the strip and page frame
+	rocks := []image.Rectangle { element.pageArea }
+	tiles := shatter.Shatter(element.entity.Bounds(), rocks...)
+	for _, tile := range tiles {
+		element.entity.DrawBackground(art.Cut(destination, tile))
+	}
+
+	// page frame, around the page
+	rocks = nil
+	if page := element.Page(); page != nil && element.entity.IndexOf(page) >= 0 {
+		rocks = append(rocks, page.Entity().Bounds())
+	}
+	tiles = shatter.Shatter(element.pageArea, rocks...)
+	for _, tile := range tiles {
+		element.DrawBackground(art.Cut(destination, tile))
+	}
+
+	// tabs
+	strip := art.Cut(destination, element.visible)
+	padding := theme.Padding(tomo.PatternButton, tabsTabCase)
+	margin  := theme.Margin(tomo.PatternButton, tabsTabCase)
+	for index, tab := range element.tabs {
+		tabBounds := element.tabBounds(index)
+		if !tabBounds.Overlaps(element.visible) { continue }
+		state := element.tabState(index)
+		theme.Pattern(tomo.PatternButton, state, tabsTabCase).Draw(strip, tabBounds)
+		foreground := theme.Color(tomo.ColorForeground, state, tabsTabCase)
+		inner := padding.Apply(tabBounds)
+		x := inner.Min.X
+
+		if tab.hasIcon {
+			icon := theme.Icon(tab.iconId, tomo.IconSizeSmall, tabsTabCase)
+			if icon != nil {
+				iconBounds := icon.Bounds()
+				icon.Draw(strip, foreground, image.Pt (
+					x, inner.Min.Y + (inner.Dy() - iconBounds.Dy()) / 2))
+				x += iconBounds.Dx() + margin.X
+			}
+		}
+
+		textBounds := tab.drawer.LayoutBounds()
+		offset := image.Pt (
+			x,
+			inner.Min.Y + (inner.Dy() - textBounds.Dy()) / 2).Sub(textBounds.Min)
+		tab.drawer.Draw(strip, foreground, offset)
+
+		if tab.closable {
+			closeBounds := element.closeBounds(index)
+			closeState := state
+			closeState.Pressed = element.closePressed == index
+			icon := theme.Icon(tomo.IconClose, tomo.IconSizeSmall, tabsCloseCase)
+			if icon != nil {
+				closeColor := theme.Color(tomo.ColorForeground, closeState, tabsCloseCase)
+				offset := closeBounds.Min
+				if closeState.Pressed {
+					offset = offset.Add(theme.Sink(tomo.PatternButton, tabsCloseCase))
+				}
+				icon.Draw(strip, closeColor, offset)
+			}
+		}
+	}
+
+	// scroll buttons
+	if element.overflowing() {
+		element.drawScrollButton (
+			destination, element.backward, tomo.IconBackward,
+			element.scroll > 0)
+		element.drawScrollButton (
+			destination, element.forward, tomo.IconForward,
+			element.scroll < element.maxScroll())
+	}
+}
+
+// Layout causes this element to perform a layout operation.
+func (element *Tabs) Layout () {
+	bounds := element.entity.Bounds()
+	element.strip = bounds
+	element.strip.Max.Y = bounds.Min.Y + element.stripHeight
+	element.pageArea = bounds
+	element.pageArea.Min.Y = element.strip.Max.Y
+
+	element.visible = element.strip
+	element.backward = image.Rectangle { }
+	element.forward  = image.Rectangle { }
+	if element.overflowing() {
+		size := element.stripHeight
+		element.forward  = image.Rect (
+			element.strip.Max.X - size, element.strip.Min.Y,
+			element.strip.Max.X,        element.strip.Max.Y)
+		element.backward = element.forward.Sub(image.Pt(size, 0))
+		element.visible.Max.X = element.backward.Min.X
+	}
+	element.scrollBy(0)
+
+	page := element.Page()
+	if page == nil { return }
+	index := element.entity.IndexOf(page)
+	if index < 0 { return }
+	padding := element.entity.Theme().Padding(tomo.PatternRaised, tabsCase)
+	element.entity.PlaceChild(index, padding.Apply(element.pageArea))
+}
+
+// DrawBackground draws this element's background pattern to the specified
+// destination canvas.
+func (element *Tabs) DrawBackground (destination art.Canvas) {
+	element.entity.Theme().Pattern(tomo.PatternRaised, tomo.State { }, tabsCase).
+		Draw(destination, element.pageArea)
+}
+
+// AddTab adds a page to the end of the tab strip, with the specified text and
+// icon on its tab. Passing tomo.IconNone creates a tab without an icon. If no
+// tab was selected before, the new tab is selected.
+func (element *Tabs) AddTab (text string, icon tomo.Icon, page tomo.Element) {
+	tab := &tabsTab { page: page }
+	tab.drawer.SetFace (element.entity.Theme().FontFace (
+		tomo.FontStyleRegular,
+		tomo.FontSizeNormal,
+		tabsTabCase))
+	tab.text = text
+	tab.drawer.SetText([]rune(text))
+	tab.hasIcon = icon != tomo.IconNone
+	tab.iconId  = icon
+	element.tabs = append(element.tabs, tab)
+	element.updateMinimumSize()
+	if element.selected < 0 {
+		element.Select(len(element.tabs) - 1)
+	} else {
+		element.entity.Invalidate()
+		element.entity.InvalidateLayout()
+	}
+}
+
+// RemoveTab removes the page at the specified index. If it was selected, the
+// tab after it is selected instead.
+func (element *Tabs) RemoveTab (index int) {
+	if index < 0 || index >= len(element.tabs) { return }
+	page := element.tabs[index].page
+	element.tabs = append(element.tabs[:index], element.tabs[index + 1:]...)
+	element.pressed = -1
+	element.closePressed = -1
+	element.updateMinimumSize()
+
+	if index != element.selected {
+		if index < element.selected { element.selected -- }
+		element.entity.Invalidate()
+		element.entity.InvalidateLayout()
+		return
+	}
+
+	// the selected tab was removed, so its page must be removed as well
+	if childIndex := element.entity.IndexOf(page); childIndex >= 0 {
+		element.entity.Disown(childIndex)
+	}
+	element.selected = -1
+	if index >= len(element.tabs) { index -- }
+	if index < 0 {
+		element.updateMinimumSize()
+		element.entity.Invalidate()
+		element.entity.InvalidateLayout()
+		if element.onSelect != nil {
+			element.onSelect()
+		}
+		return
+	}
+	element.Select(index)
+}
+
+// MoveTab moves the tab at one index to another. The selected tab remains
+// selected.
+func (element *Tabs) MoveTab (from, to int) {
+	if from < 0 || from >= len(element.tabs) { return }
+	if to   < 0 { to = 0 }
+	if to  >= len(element.tabs) { to = len(element.tabs) - 1 }
+	if from == to { return }
+
+	tab := element.tabs[from]
+	element.tabs = append(element.tabs[:from], element.tabs[from + 1:]...)
+	element.tabs = append(element.tabs[:to], append([]*tabsTab { tab }, element.tabs[to:]...)...)
+
+	switch {
+	case element.selected == from:
+		element.selected = to
+	case from < element.selected && to >= element.selected:
+		element.selected --
+	case from > element.selected && to <= element.selected:
+		element.selected ++
+	}
+	element.entity.Invalidate()
+}
+
+// CountTabs returns the amount of tabs.
+func (element *Tabs) CountTabs () int {
+	return len(element.tabs)
+}
+
+// Tab returns the page at the specified index.
+func (element *Tabs) Tab (index int) tomo.Element {
+	if index < 0 || index >= len(element.tabs) { return nil }
+	return element.tabs[index].page
+}
+
+// IndexOf returns the index of the specified page, or -1 if it is not in the
+// container.
+func (element *Tabs) IndexOf (page tomo.Element) int {
+	for index, tab := range element.tabs {
+		if tab.page == page { return index }
+	}
+	return -1
+}
+
+// SetTabText sets the text displayed on the tab at the specified index.
+func (element *Tabs) SetTabText (index int, text string) {
+	if index < 0 || index >= len(element.tabs) { return }
+	tab := element.tabs[index]
+	if tab.text == text { return }
+	tab.text = text
+	tab.drawer.SetText([]rune(text))
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+// SetTabIcon sets the icon displayed on the tab at the specified index.
+// Passing tomo.IconNone removes it.
+func (element *Tabs) SetTabIcon (index int, icon tomo.Icon) {
+	if index < 0 || index >= len(element.tabs) { return }
+	tab := element.tabs[index]
+	tab.hasIcon = icon != tomo.IconNone
+	tab.iconId  = icon
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+// SetClosable sets whether the tab at the specified index has a close button.
+func (element *Tabs) SetClosable (index int, closable bool) {
+	if index < 0 || index >= len(element.tabs) { return }
+	tab := element.tabs[index]
+	if tab.closable == closable { return }
+	tab.closable = closable
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+// Selected returns the index of the selected tab, or -1 if there are none.
+func (element *Tabs) Selected () int {
+	return element.selected
+}
+
+// Page returns the page that is currently shown, or nil if there are no tabs.
+func (element *Tabs) Page () tomo.Element {
+	return element.Tab(element.selected)
+}
+
+// Select shows the page at the specified index.
+func (element *Tabs) Select (index int) {
+	if index < -1 || index >= len(element.tabs) { return }
+	if element.selected == index { return }
+
+	if page := element.Page(); page != nil {
+		if childIndex := element.entity.IndexOf(page); childIndex >= 0 {
+			element.entity.Disown(childIndex)
+		}
+	}
+	element.selected = index
+	if page := element.Page(); page != nil {
+		element.entity.Adopt(page)
+	}
+
+	element.updateMinimumSize()
+	element.scrollToTab(index)
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+	if element.onSelect != nil {
+		element.onSelect()
+	}
+}
+
+// OnSelect sets a function to be called when a different tab is selected.
+func (element *Tabs) OnSelect (callback func ()) {
+	element.onSelect = callback
+}
+
+// OnTabClose sets a function to be called when the user tries to close a tab.
+// If it returns false, the tab is not closed. If it is not set, tabs are
+// always closed.
+func (element *Tabs) OnTabClose (callback func (page tomo.Element) bool) {
+	element.onTabClose = callback
+}
+
+// CloseTab asks to close the tab at the specified index, as if the user had
+// clicked its close button. It returns whether the tab was closed.
+func (element *Tabs) CloseTab (index int) bool {
+	if index < 0 || index >= len(element.tabs) { return false }
+	if element.onTabClose != nil && !element.onTabClose(element.tabs[index].page) {
+		return false
+	}
+	element.RemoveTab(element.IndexOf(element.tabs[index].page))
+	return true
+}
+
+// RegisterAccelerators binds Ctrl+Tab and Ctrl+Page Down to selecting the next
+// tab, and Ctrl+Shift+Tab and Ctrl+Page Up to selecting the previous one, in
+// the accelerator table of the specified window. This allows the user to
+// switch tabs even while a page has keyboard focus.
+func (element *Tabs) RegisterAccelerators (window tomo.Window) error {
+	table := window.Accelerators()
+	next     := func () { element.cycle(1)  }
+	previous := func () { element.cycle(-1) }
+	err := table.Add(tomo.A(input.KeyTab, input.Modifiers { Control: true }), next)
+	if err != nil { return err }
+	err = table.Add(tomo.A(input.KeyPageDown, input.Modifiers { Control: true }), next)
+	if err != nil { return err }
+	err = table.Add (
+		tomo.A(input.KeyTab, input.Modifiers { Control: true, Shift: true }),
+		previous)
+	if err != nil { return err }
+	return table.Add(tomo.A(input.KeyPageUp, input.Modifiers { Control: true }), previous)
+}
+
+// Focus gives this element input focus.
+func (element *Tabs) Focus () {
+	if !element.entity.Focused() { element.entity.Focus() }
+}
+
+// Enabled returns whether the tabs can be interacted with.
+func (element *Tabs) Enabled () bool {
+	return element.enabled
+}
+
+// SetEnabled sets whether the tabs can be interacted with. This does not
+// affect the pages themselves.
+func (element *Tabs) SetEnabled (enabled bool) {
+	if element.enabled == enabled { return }
+	element.enabled = enabled
+	element.entity.Invalidate()
+}
+
+func (element *Tabs) HandleFocusChange () {
+	element.entity.Invalidate()
+}
+
+func (element *Tabs) HandleMouseDown (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) {
+	if !element.Enabled() { return }
+
+	if position.In(element.backward) {
+		element.scrollBy(-element.stripHeight * 2)
+		return
+	}
+	if position.In(element.forward) {
+		element.scrollBy(element.stripHeight * 2)
+		return
+	}
+
+	index := element.tabAt(position)
+	if index < 0 { return }
+	switch button {
+	case input.ButtonLeft:
+		if element.tabs[index].closable && position.In(element.closeBounds(index)) {
+			element.closePressed = index
+			element.entity.Invalidate()
+			return
+		}
+		element.Focus()
+		element.pressed = index
+		element.Select(index)
+		element.entity.Invalidate()
+	case input.ButtonMiddle:
+		if element.tabs[index].closable {
+			element.CloseTab(index)
+		}
+	}
+}
+
+func (element *Tabs) HandleMouseUp (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) {
+	if button != input.ButtonLeft { return }
+	if element.closePressed >= 0 {
+		index := element.closePressed
+		element.closePressed = -1
+		element.entity.Invalidate()
+		if position.In(element.closeBounds(index)) && position.In(element.visible) {
+			element.CloseTab(index)
+		}
+		return
+	}
+	if element.pressed >= 0 {
+		element.pressed = -1
+		element.entity.Invalidate()
+	}
+}
+
+func (element *Tabs) HandleMotion (position image.Point) {
+	if element.pressed < 0 { return }
+
+	// swap the dragged tab with its neighbors once the mouse passes their
+	// centers
+	center := func (index int) int {
+		bounds := element.tabBounds(index)
+		return (bounds.Min.X + bounds.Max.X) / 2
+	}
+	for element.pressed > 0 && position.X < center(element.pressed - 1) {
+		element.MoveTab(element.pressed, element.pressed - 1)
+		element.pressed --
+	}
+	for element.pressed < len(element.tabs) - 1 && position.X > center(element.pressed + 1) {
+		element.MoveTab(element.pressed, element.pressed + 1)
+		element.pressed ++
+	}
+}
+
+func (element *Tabs) HandleScroll (
+	position image.Point,
+	deltaX, deltaY float64,
+	modifiers input.Modifiers,
+) {
+	if !position.In(element.strip) { return }
+	element.scrollBy(int(deltaX + deltaY))
+}
+
+func (element *Tabs) HandleKeyDown (key input.Key, modifiers input.Modifiers) {
+	if !element.Enabled() { return }
+	switch {
+	case key == input.KeyTab && modifiers.Control:
+		if modifiers.Shift {
+			element.cycle(-1)
+		} else {
+			element.cycle(1)
+		}
+	case key == input.KeyPageUp && modifiers.Control && modifiers.Shift:
+		element.MoveTab(element.selected, element.selected - 1)
+		element.scrollToTab(element.selected)
+	case key == input.KeyPageDown && modifiers.Control && modifiers.Shift:
+		element.MoveTab(element.selected, element.selected + 1)
+		element.scrollToTab(element.selected)
+	case key == input.KeyPageUp && modifiers.Control:
+		element.cycle(-1)
+	case key == input.KeyPageDown && modifiers.Control:
+		element.cycle(1)
+	case key == input.KeyLeft:
+		if element.selected > 0 { element.Select(element.selected - 1) }
+	case key == input.KeyRight:
+		element.Select(element.selected + 1)
+	case key == input.KeyHome:
+		element.Select(0)
+	case key == input.KeyEnd:
+		element.Select(len(element.tabs) - 1)
+	case key == input.KeyDelete:
+		if element.selected >= 0 && element.tabs[element.selected].closable {
+			element.CloseTab(element.selected)
+		}
+	}
+}
+
+func (element *Tabs) HandleKeyUp (key input.Key, modifiers input.Modifiers) { }
+
+func (element *Tabs) HandleChildMinimumSizeChange (child tomo.Element) {
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+func (element *Tabs) HandleThemeChange () {
+	face := element.entity.Theme().FontFace (
+		tomo.FontStyleRegular,
+		tomo.FontSizeNormal,
+		tabsTabCase)
+	for _, tab := range element.tabs {
+		tab.drawer.SetFace(face)
+	}
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+// cycle selects the tab a certain distance away from the selected one,
+// wrapping around at the ends.
+func (element *Tabs) cycle (delta int) {
+	if len(element.tabs) == 0 { return }
+	index := (element.selected + delta) % len(element.tabs)
+	if index < 0 { index += len(element.tabs) }
+	element.Select(index)
+}
+
+func (element *Tabs) drawScrollButton (
+	destination art.Canvas,
+	bounds image.Rectangle,
+	id tomo.Icon,
+	enabled bool,
+) {
+	theme := element.entity.Theme()
+	state := tomo.State { Disabled: !enabled || !element.Enabled() }
+	theme.Pattern(tomo.PatternButton, state, tabsScrollCase).Draw(destination, bounds)
+	icon := theme.Icon(id, tomo.IconSizeSmall, tabsScrollCase)
+	if icon == nil { return }
+	iconBounds := icon.Bounds()
+	icon.Draw (
+		destination,
+		theme.Color(tomo.ColorForeground, state, tabsScrollCase),
+		bounds.Min.Add(image.Pt (
+			(bounds.Dx() - iconBounds.Dx()) / 2,
+			(bounds.Dy() - iconBounds.Dy()) / 2)))
+}
+
+func (element *Tabs) tabState (index int) tomo.State {
+	return tomo.State {
+		Disabled: !element.Enabled(),
+		Focused:  element.entity.Focused() && index == element.selected,
+		Pressed:  index == element.pressed,
+		On:       index == element.selected,
+	}
+}
+
+// tabBounds returns the bounds of the tab at the specified index, taking the
+// scroll position into account.
+func (element *Tabs) tabBounds (index int) image.Rectangle {
+	x := element.visible.Min.X - element.scroll
+	for _, tab := range element.tabs[:index] {
+		x += tab.width
+	}
+	return image.Rect (
+		x, element.strip.Min.Y,
+		x + element.tabs[index].width, element.strip.Max.Y)
+}
+
+// closeBounds returns the bounds of the close button of the tab at the
+// specified index.
+func (element *Tabs) closeBounds (index int) image.Rectangle {
+	padding := element.entity.Theme().Padding(tomo.PatternButton, tabsTabCase)
+	inner := padding.Apply(element.tabBounds(index))
+	size := element.closeSize()
+	return image.Rect (
+		inner.Max.X - size.X, inner.Min.Y + (inner.Dy() - size.Y) / 2,
+		inner.Max.X,          inner.Min.Y + (inner.Dy() - size.Y) / 2 + size.Y)
+}
+
+func (element *Tabs) closeSize () image.Point {
+	icon := element.entity.Theme().Icon(tomo.IconClose, tomo.IconSizeSmall, tabsCloseCase)
+	if icon == nil { return image.Point { } }
+	return icon.Bounds().Size()
+}
+
+func (element *Tabs) tabAt (position image.Point) int {
+	if !position.In(element.visible) { return -1 }
+	for index := range element.tabs {
+		if position.In(element.tabBounds(index)) { return index }
+	}
+	return -1
+}
+
+func (element *Tabs) contentWidth () (width int) {
+	for _, tab := range element.tabs {
+		width += tab.width
+	}
+	return
+}
+
+func (element *Tabs) overflowing () bool {
+	return element.contentWidth() > element.strip.Dx()
+}
+
+func (element *Tabs) maxScroll () int {
+	maxScroll := element.contentWidth() - element.visible.Dx()
+	if maxScroll < 0 { maxScroll = 0 }
+	return maxScroll
+}
+
+func (element *Tabs) scrollBy (delta int) {
+	scroll := element.scroll + delta
+	if scroll > element.maxScroll() { scroll = element.maxScroll() }
+	if scroll < 0 { scroll = 0 }
+	if scroll == element.scroll { return }
+	element.scroll = scroll
+	element.entity.Invalidate()
+}
+
+// scrollToTab scrolls the strip so that the tab at the specified index is
+// fully visible.
+func (element *Tabs) scrollToTab (index int) {
+	if index < 0 || index >= len(element.tabs) { return }
+	bounds := element.tabBounds(index)
+	if bounds.Min.X < element.visible.Min.X {
+		element.scrollBy(bounds.Min.X - element.visible.Min.X)
+	} else if bounds.Max.X > element.visible.Max.X {
+		element.scrollBy(bounds.Max.X - element.visible.Max.X)
+	}
+}
+
+func (element *Tabs) updateMinimumSize () {
+	theme   := element.entity.Theme()
+	padding := theme.Padding(tomo.PatternButton, tabsTabCase)
+	margin  := theme.Margin(tomo.PatternButton, tabsTabCase)
+	closeSize := element.closeSize()
+
+	// measure each tab, and the height of the strip
+	var contentHeight int
+	for _, tab := range element.tabs {
+		textBounds := tab.drawer.LayoutBounds()
+		width := textBounds.Dx()
+		if textBounds.Dy() > contentHeight { contentHeight = textBounds.Dy() }
+		if tab.hasIcon {
+			icon := theme.Icon(tab.iconId, tomo.IconSizeSmall, tabsTabCase)
+			if icon != nil {
+				iconBounds := icon.Bounds()
+				width += iconBounds.Dx() + margin.X
+				if iconBounds.Dy() > contentHeight {
+					contentHeight = iconBounds.Dy()
+				}
+			}
+		}
+		if tab.closable {
+			width += closeSize.X + margin.X
+			if closeSize.Y > contentHeight { contentHeight = closeSize.Y }
+		}
+		tab.width = width + padding.Horizontal()
+	}
+	element.stripHeight = contentHeight + padding.Vertical()
+
+	// the strip must at least be able to fit its scroll buttons and part
+	// of a tab
+	width  := element.stripHeight * 3
+	height := element.stripHeight
+	pagePadding := theme.Padding(tomo.PatternRaised, tabsCase)
+	pageWidth, pageHeight := pagePadding.Horizontal(), pagePadding.Vertical()
+	if page := element.Page(); page != nil {
+		if index := element.entity.IndexOf(page); index >= 0 {
+			childWidth, childHeight := element.entity.ChildMinimumSize(index)
+			pageWidth  += childWidth
+			pageHeight += childHeight
+		}
+	}
+	if pageWidth > width { width = pageWidth }
+	height += pageHeight
+	element.entity.SetMinimumSize(width, height)
+}
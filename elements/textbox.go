@@ -1,10 +1,13 @@
 package elements
 
 import "io"
+import "errors"
 import "time"
 import "image"
 import "tomo"
 import "tomo/data"
+import "tomo/menu"
+import "tomo/validate"
 import "tomo/input"
 import "art"
 import "tomo/textdraw"
@@ -14,7 +17,9 @@ import "art/shapes"
 
 var textBoxCase = tomo.C("tomo", "textBox")
 
-// TextBox is a single-line text input.
+// TextBox is a single-line text input. It can be put into a masked mode for
+// entering passwords, where each character of its value is displayed as a mask
+// character instead.
 type TextBox struct {
 	entity tomo.Entity
 	
@@ -25,6 +30,15 @@ type TextBox struct {
 	scroll      int
 	placeholder string
 	text        []rune
+
+	mask       rune
+	revealed   bool
+	revealable bool
+
+	validator     validate.Validator
+	validationErr error
+
+	accelerators tomo.AcceleratorTable
 	
 	placeholderDrawer textdraw.Drawer
 	valueDrawer       textdraw.Drawer
@@ -32,6 +46,7 @@ type TextBox struct {
 	onKeyDown func (key input.Key, modifiers input.Modifiers) (handled bool)
 	onChange  func ()
 	onEnter   func ()
+	onFocusChange        func ()
 	onScrollBoundsChange func ()
 }
 
@@ -51,6 +66,12 @@ func NewTextBox (placeholder, value string) (element *TextBox) {
 	element.placeholderDrawer.SetText([]rune(placeholder))
 	element.updateMinimumSize()
 	element.SetValue(value)
+
+	control := input.Modifiers { Control: true }
+	element.accelerators.Add(tomo.A('a', control), element.SelectAll)
+	element.accelerators.Add(tomo.A('x', control), element.Cut)
+	element.accelerators.Add(tomo.A('c', control), element.Copy)
+	element.accelerators.Add(tomo.A('v', control), element.Paste)
 	return
 }
 
@@ -65,11 +86,31 @@ func (element *TextBox) Draw (destination art.Canvas) {
 
 	state := element.state()
 	pattern := element.entity.Theme().Pattern(tomo.PatternInput, state, textBoxCase)
-	padding := element.entity.Theme().Padding(tomo.PatternInput, textBoxCase)
-	innerCanvas := art.Cut(destination, padding.Apply(bounds))
+	innerCanvas := art.Cut(destination, element.innerBounds())
 	pattern.Draw(destination, bounds)
 	offset := element.textOffset()
 
+	if element.showRevealButton() {
+		// draw reveal button
+		revealBounds := element.revealBounds()
+		revealState  := tomo.State {
+			Disabled: !element.Enabled(),
+			On:       element.revealed,
+		}
+		element.entity.Theme().Pattern (
+			tomo.PatternButton, revealState, textBoxCase).
+			Draw(destination, revealBounds)
+		if icon := element.revealIcon(); icon != nil {
+			iconBounds := icon.Bounds()
+			foreground := element.entity.Theme().Color (
+				tomo.ColorForeground, revealState, textBoxCase)
+			icon.Draw (destination, foreground, image.Pt (
+				revealBounds.Min.X + (revealBounds.Dx() - iconBounds.Dx()) / 2,
+				revealBounds.Min.Y + (revealBounds.Dy() - iconBounds.Dy()) / 2).
+				Sub(iconBounds.Min))
+		}
+	}
+
 	if element.entity.Focused() && !element.dot.Empty() {
 		// draw selection bounds
 		accent := element.entity.Theme().Color(tomo.ColorAccent, state, textBoxCase)
@@ -130,6 +171,9 @@ func (element *TextBox) Layout () {
 
 func (element *TextBox) HandleFocusChange () {
 	element.entity.Invalidate()
+	if element.onFocusChange != nil {
+		element.onFocusChange()
+	}
 }
 
 func (element *TextBox) HandleMouseDown  (
@@ -142,12 +186,22 @@ func (element *TextBox) HandleMouseDown  (
 
 	switch button {
 	case input.ButtonLeft:
+		if element.showRevealButton() && position.In(element.revealBounds()) {
+			element.SetRevealed(!element.revealed)
+			return
+		}
+	
 		runeIndex := element.atPosition(position)
 		if runeIndex == -1 { return }
 		
 		if time.Since(element.lastClick) < element.entity.Config().DoubleClickDelay() {
 			element.dragging = 2
-			element.dot = textmanip.WordAround(element.text, runeIndex)
+			if element.hidden() {
+				// don't give away where the words are
+				element.dot = textmanip.Dot { End: len(element.text) }
+			} else {
+				element.dot = textmanip.WordAround(element.text, runeIndex)
+			}
 		} else {
 			element.dragging = 1
 			element.dot = textmanip.EmptyDot(runeIndex)
@@ -155,8 +209,6 @@ func (element *TextBox) HandleMouseDown  (
 		}
 		
 		element.entity.Invalidate()
-	case input.ButtonRight:
-		element.contextMenu(position)
 	}
 }
 
@@ -183,7 +235,7 @@ func (element *TextBox) HandleMotion (position image.Point) {
 		
 	case 2:
 		runeIndex := element.atPosition(position)
-		if runeIndex > -1 {
+		if runeIndex > -1 && !element.hidden() {
 			if runeIndex < element.dot.Start {
 				element.dot.End =
 					runeIndex -
@@ -223,9 +275,16 @@ func (element *TextBox) HandleKeyDown(key input.Key, modifiers input.Modifiers)
 	if element.onKeyDown != nil && element.onKeyDown(key, modifiers) {
 		return
 	}
+	if element.accelerators.Activate(key, modifiers) {
+		return
+	}
 
 	scrollMemory := element.scroll
+	oldText, oldDot := element.text, element.dot
 	textChanged := false
+	// moving by words would give away where the spaces are in a hidden
+	// value
+	wordwise := modifiers.Control && !element.hidden()
 	switch {
 	case key == input.KeyEnter:
 		if element.onEnter != nil {
@@ -237,7 +296,7 @@ func (element *TextBox) HandleKeyDown(key input.Key, modifiers input.Modifiers)
 		element.text, element.dot = textmanip.Backspace (
 			element.text,
 			element.dot,
-			modifiers.Control)
+			wordwise)
 		textChanged = true
 			
 	case key == input.KeyDelete:
@@ -245,7 +304,7 @@ func (element *TextBox) HandleKeyDown(key input.Key, modifiers input.Modifiers)
 		element.text, element.dot = textmanip.Delete (
 			element.text,
 			element.dot,
-			modifiers.Control)
+			wordwise)
 		textChanged = true
 			
 	case key == input.KeyLeft:
@@ -253,12 +312,12 @@ func (element *TextBox) HandleKeyDown(key input.Key, modifiers input.Modifiers)
 			element.dot = textmanip.SelectLeft (
 				element.text,
 				element.dot,
-				modifiers.Control)
+				wordwise)
 		} else {
 			element.dot = textmanip.MoveLeft (
 				element.text,
 				element.dot,
-				modifiers.Control)
+				wordwise)
 		}
 		element.scrollToCursor()
 		element.entity.Invalidate()
@@ -268,32 +327,16 @@ func (element *TextBox) HandleKeyDown(key input.Key, modifiers input.Modifiers)
 			element.dot = textmanip.SelectRight (
 				element.text,
 				element.dot,
-				modifiers.Control)
+				wordwise)
 		} else {
 			element.dot = textmanip.MoveRight (
 				element.text,
 				element.dot,
-				modifiers.Control)
+				wordwise)
 		}
 		element.scrollToCursor()
 		element.entity.Invalidate()
 
-	case key == 'a' && modifiers.Control:
-		element.dot.Start = 0
-		element.dot.End   = len(element.text)
-		element.scrollToCursor()
-		element.entity.Invalidate()
-
-	case key == 'x' && modifiers.Control: element.Cut()
-	case key == 'c' && modifiers.Control: element.Copy()
-	case key == 'v' && modifiers.Control: element.Paste()
-
-	case key == input.KeyMenu:
-		pos := fixedutil.RoundPt(element.valueDrawer.PositionAt(element.dot.End)).
-			Add(element.textOffset())
-		pos.Y += element.valueDrawer.LineHeight().Round()
-		element.contextMenu(pos)
-		
 	case key.Printable():
 		element.text, element.dot = textmanip.Type (
 			element.text,
@@ -302,9 +345,12 @@ func (element *TextBox) HandleKeyDown(key input.Key, modifiers input.Modifiers)
 		textChanged = true
 	}
 
+	if textChanged {
+		textChanged = element.validate(oldText, oldDot)
+	}
 	if textChanged {
 		element.runOnChange()
-		element.valueDrawer.SetText(element.text)
+		element.updateValueDrawer()
 		element.scrollToCursor()
 		element.entity.Invalidate()
 	}
@@ -314,20 +360,33 @@ func (element *TextBox) HandleKeyDown(key input.Key, modifiers input.Modifiers)
 	}
 }
 
+// SelectAll selects all of the text in the text box.
+func (element *TextBox) SelectAll () {
+	element.dot.Start = 0
+	element.dot.End   = len(element.text)
+	element.scrollToCursor()
+	element.entity.Invalidate()
+}
+
 // Cut cuts the selected text in the text box and places it in the clipboard.
+// This does nothing if the text box is masked.
 func (element *TextBox) Cut () {
+	if element.mask != 0 { return }
+	oldText, oldDot := element.text, element.dot
 	var lifted []rune
 	element.text, element.dot, lifted = textmanip.Lift (
 		element.text,
 		element.dot)
-	if lifted != nil {
+	if lifted != nil && element.validate(oldText, oldDot) {
 		element.clipboardPut(lifted)
 		element.notifyAsyncTextChange()
 	}
 }
 
 // Copy copies the selected text in the text box and places it in the clipboard.
+// This does nothing if the text box is masked.
 func (element *TextBox) Copy () {
+	if element.mask != 0 { return }
 	element.clipboardPut(element.dot.Slice(element.text))
 }
 
@@ -340,10 +399,12 @@ func (element *TextBox) Paste () {
 		reader, ok := d[data.MimePlain]
 		if !ok { return }
 		bytes, _ := io.ReadAll(reader)
+		oldText, oldDot := element.text, element.dot
 		element.text, element.dot = textmanip.Type (
 			element.text,
 			element.dot,
 			[]rune(string(bytes))...)
+		if !element.validate(oldText, oldDot) { return }
 		element.notifyAsyncTextChange()
 	})
 }
@@ -366,8 +427,9 @@ func (element *TextBox) SetValue (text string) {
 	// if element.text == text { return }
 
 	element.text = []rune(text)
+	element.check()
 	element.runOnChange()
-	element.valueDrawer.SetText(element.text)
+	element.updateValueDrawer()
 	if element.dot.End > element.valueDrawer.Length() {
 		element.dot = textmanip.EmptyDot(element.valueDrawer.Length())
 	}
@@ -380,11 +442,102 @@ func (element *TextBox) Value () (value string) {
 	return string(element.text)
 }
 
+// SetValidator sets a function that checks every edit the user makes to the
+// text box. It can reject edits, transform them, or mark the text box as
+// invalid, which causes it to be drawn differently. Values set with SetValue
+// are checked, but never rejected or transformed. Passing nil removes the
+// validator. See the validate package for common validators.
+func (element *TextBox) SetValidator (validator validate.Validator) {
+	element.validator = validator
+	element.check()
+	element.entity.Invalidate()
+}
+
+// Valid returns whether the text box's value passed its validator.
+func (element *TextBox) Valid () bool {
+	return element.validationErr == nil
+}
+
+// ValidationError returns the error reported by the text box's validator for
+// its current value, or nil if the value is valid. The error message should be
+// displayed to the user.
+func (element *TextBox) ValidationError () error {
+	return element.validationErr
+}
+
+// SetMask sets the character that is displayed in place of each character of
+// the text box's value, such as '•' for a password field. Passing zero turns
+// masking off. While a text box is masked, its value cannot be cut or copied to
+// the clipboard, even if it is revealed.
+func (element *TextBox) SetMask (mask rune) {
+	if element.mask == mask { return }
+	element.mask = mask
+	element.updateValueDrawer()
+	element.updateMinimumSize()
+	element.scrollToCursor()
+	element.entity.Invalidate()
+}
+
+// Mask returns the character that the text box's value is masked with, or zero
+// if it is not masked.
+func (element *TextBox) Mask () rune {
+	return element.mask
+}
+
+// SetRevealed sets whether the value of a masked text box is displayed as
+// plain text.
+func (element *TextBox) SetRevealed (revealed bool) {
+	if element.revealed == revealed { return }
+	element.revealed = revealed
+	element.updateValueDrawer()
+	element.scrollToCursor()
+	element.entity.Invalidate()
+}
+
+// Revealed returns whether the value of a masked text box is displayed as plain
+// text.
+func (element *TextBox) Revealed () bool {
+	return element.revealed
+}
+
+// SetRevealable sets whether a masked text box has a button that lets the user
+// reveal its value.
+func (element *TextBox) SetRevealable (revealable bool) {
+	if element.revealable == revealable { return }
+	element.revealable = revealable
+	element.updateMinimumSize()
+	element.scrollToCursor()
+	element.entity.Invalidate()
+}
+
+// Dot returns the text box's cursor and selection.
+func (element *TextBox) Dot () textmanip.Dot {
+	return element.dot
+}
+
+// SetDot sets the text box's cursor and selection. It is constrained to the
+// length of the value.
+func (element *TextBox) SetDot (dot textmanip.Dot) {
+	dot = dot.Constrain(len(element.text))
+	if element.dot == dot { return }
+	element.dot = dot
+	element.scrollToCursor()
+	element.entity.Invalidate()
+}
+
 // Filled returns whether or not this element has a value.
 func (element *TextBox) Filled () (filled bool) {
 	return len(element.text) > 0
 }
 
+// Accelerators returns the text box's table of editing shortcuts. By default,
+// Ctrl+A selects all text, and Ctrl+X, Ctrl+C and Ctrl+V cut, copy and paste.
+// Shortcuts can be removed from the table or bound to different keys. The table
+// is consulted after the OnKeyDown callback, but before any other key handling.
+func (element *TextBox) Accelerators () *tomo.AcceleratorTable {
+	return &element.accelerators
+}
+
 // OnKeyDown specifies a function to be called when a key is pressed within the
 // text input.
 func (element *TextBox) OnKeyDown (
@@ -405,6 +558,12 @@ func (element *TextBox) OnChange (callback func ()) {
 	element.onChange = callback
 }
 
+// OnFocusChange specifies a function to be called when this input gains or
+// loses keyboard focus.
+func (element *TextBox) OnFocusChange (callback func ()) {
+	element.onFocusChange = callback
+}
+
 // OnScrollBoundsChange sets a function to be called when the element's viewport
 // bounds, content bounds, or scroll axes change.
 func (element *TextBox) OnScrollBoundsChange (callback func ()) {
@@ -476,40 +635,69 @@ func (element *TextBox) HandleThemeChange () {
 	element.entity.Invalidate()
 }
 
-func (element *TextBox) contextMenu (position image.Point) {
-	window := element.entity.Window()
-	menu, err := window.NewMenu(image.Rectangle { position, position })
-	if err != nil { return }
-
-	closeAnd := func (callback func ()) func () {
-		return func () { callback(); menu.Close() }
+// ContextMenu returns the items to display in the text box's context menu.
+func (element *TextBox) ContextMenu (position image.Point) menu.Menu {
+	if !element.Enabled() { return nil }
+	control := input.Modifiers { Control: true }
+	// the default shortcuts are only displayed if they haven't been
+	// unbound
+	shortcut := func (key input.Key) tomo.Accelerator {
+		accelerator := tomo.A(key, control)
+		if !element.accelerators.Has(accelerator) {
+			return tomo.Accelerator { }
+		}
+		return accelerator
 	}
-
-	cutButton := NewButton("Cut")
-	cutButton.ShowText(false)
-	cutButton.SetIcon(tomo.IconCut)
-	cutButton.SetEnabled(!element.dot.Empty())
-	cutButton.OnClick(closeAnd(element.Cut))
 	
-	copyButton := NewButton("Copy")
-	copyButton.ShowText(false)
-	copyButton.SetIcon(tomo.IconCopy)
-	copyButton.SetEnabled(!element.dot.Empty())
-	copyButton.OnClick(closeAnd(element.Copy))
+	cutItem := menu.Action("Cu_t", element.Cut)
+	cutItem.Icon        = tomo.IconCut
+	cutItem.Accelerator = shortcut('x')
+	cutItem.Disabled    = element.dot.Empty() || element.mask != 0
+	
+	copyItem := menu.Action("_Copy", element.Copy)
+	copyItem.Icon        = tomo.IconCopy
+	copyItem.Accelerator = shortcut('c')
+	copyItem.Disabled    = element.dot.Empty() || element.mask != 0
 	
-	pasteButton := NewButton("Paste")
-	pasteButton.ShowText(false)
-	pasteButton.SetIcon(tomo.IconPaste)
-	pasteButton.OnClick(closeAnd(element.Paste))
+	pasteItem := menu.Action("_Paste", element.Paste)
+	pasteItem.Icon        = tomo.IconPaste
+	pasteItem.Accelerator = shortcut('v')
+
+	return menu.Menu { cutItem, copyItem, pasteItem }
+}
+
+// ContextMenuAnchor returns the position just below the text cursor, which is
+// where the context menu opens when it is requested with the keyboard.
+func (element *TextBox) ContextMenuAnchor () image.Point {
+	position := fixedutil.RoundPt(element.valueDrawer.PositionAt(element.dot.End)).
+		Add(element.textOffset())
+	position.Y += element.valueDrawer.LineHeight().Round()
+	return position
+}
+
+// validate runs the validator over an edit that has already been made. If the
+// edit is rejected, the old text and dot are restored and false is returned.
+func (element *TextBox) validate (oldText []rune, oldDot textmanip.Dot) bool {
+	if element.validator == nil { return true }
+	value, err := element.validator(string(element.text))
+	if errors.Is(err, validate.ErrReject) {
+		element.text = oldText
+		element.dot  = oldDot
+		return false
+	}
+	if value != string(element.text) {
+		element.text = []rune(value)
+		element.dot  = element.dot.Constrain(len(element.text))
+	}
+	element.validationErr = err
+	return true
+}
 
-	menu.Adopt (NewHBox (
-		SpaceNone,
-		pasteButton,
-		copyButton,
-		cutButton,
-	))
-	pasteButton.Focus()
-	menu.Show()
+// check runs the validator over the current value without changing it.
+func (element *TextBox) check () {
+	element.validationErr = nil
+	if element.validator == nil { return }
+	_, element.validationErr = element.validator(string(element.text))
 }
 
 func (element *TextBox) runOnChange () {
@@ -519,13 +707,72 @@ func (element *TextBox) runOnChange () {
 }
 
 func (element *TextBox) scrollViewportWidth () (width int) {
-	padding := element.entity.Theme().Padding(tomo.PatternInput, textBoxCase)
-	return padding.Apply(element.entity.Bounds()).Dx()
+	return element.innerBounds().Dx()
 }
 
-func (element *TextBox) scrollToCursor () {
+// innerBounds returns the area that the text is drawn in.
+func (element *TextBox) innerBounds () image.Rectangle {
 	padding := element.entity.Theme().Padding(tomo.PatternInput, textBoxCase)
 	bounds  := padding.Apply(element.entity.Bounds())
+	if element.showRevealButton() {
+		bounds.Max.X = element.revealBounds().Min.X
+	}
+	return bounds
+}
+
+// revealBounds returns the area taken up by the reveal button.
+func (element *TextBox) revealBounds () image.Rectangle {
+	padding := element.entity.Theme().Padding(tomo.PatternInput, textBoxCase)
+	bounds  := element.entity.Bounds()
+	bounds.Min.X = bounds.Max.X - element.revealWidth()
+	bounds.Max.X -= padding[art.SideRight]
+	bounds.Min.Y += padding[art.SideTop]
+	bounds.Max.Y -= padding[art.SideBottom]
+	return bounds
+}
+
+func (element *TextBox) revealWidth () int {
+	if !element.showRevealButton() { return 0 }
+	buttonPadding := element.entity.Theme().Padding(tomo.PatternButton, textBoxCase)
+	inputPadding  := element.entity.Theme().Padding(tomo.PatternInput, textBoxCase)
+	iconWidth := 0
+	if icon := element.revealIcon(); icon != nil {
+		iconWidth = icon.Bounds().Dx()
+	}
+	return iconWidth +
+		buttonPadding.Horizontal() +
+		inputPadding[art.SideRight]
+}
+
+// revealIcon returns the icon displayed on the reveal button, which shows what
+// pressing it will do.
+func (element *TextBox) revealIcon () art.Icon {
+	id := tomo.IconReveal
+	if element.revealed { id = tomo.IconConceal }
+	return element.entity.Theme().Icon(id, tomo.IconSizeSmall, textBoxCase)
+}
+
+func (element *TextBox) showRevealButton () bool {
+	return element.mask != 0 && element.revealable
+}
+
+// hidden returns whether the text box's value is currently being masked.
+func (element *TextBox) hidden () bool {
+	return element.mask != 0 && !element.revealed
+}
+
+func (element *TextBox) updateValueDrawer () {
+	if element.hidden() {
+		masked := make([]rune, len(element.text))
+		for index := range masked { masked[index] = element.mask }
+		element.valueDrawer.SetText(masked)
+	} else {
+		element.valueDrawer.SetText(element.text)
+	}
+}
+
+func (element *TextBox) scrollToCursor () {
+	bounds := element.innerBounds()
 	bounds = bounds.Sub(bounds.Min)
 	bounds.Max.X -= element.valueDrawer.Em().Round()
 	cursorPosition := fixedutil.RoundPt (
@@ -549,19 +796,21 @@ func (element *TextBox) updateMinimumSize () {
 	textBounds := element.placeholderDrawer.LayoutBounds()
 	padding := element.entity.Theme().Padding(tomo.PatternInput, textBoxCase)
 	element.entity.SetMinimumSize (
-		padding.Horizontal() + textBounds.Dx(),
+		padding.Horizontal() + textBounds.Dx() + element.revealWidth(),
 		padding.Vertical()   +
 		element.placeholderDrawer.LineHeight().Round())
 }
 
 func (element *TextBox) notifyAsyncTextChange () {
 	element.runOnChange()
-	element.valueDrawer.SetText(element.text)
+	element.updateValueDrawer()
 	element.scrollToCursor()
 	element.entity.Invalidate()
 }
 
 func (element *TextBox) clipboardPut (text []rune) {
+	// never let a password anywhere near the clipboard
+	if element.mask != 0 { return }
 	window := element.entity.Window()
 	if window != nil {
 		window.Copy(data.Bytes(data.MimePlain, []byte(string(text))))
@@ -572,5 +821,6 @@ func (element *TextBox) state () tomo.State {
 	return tomo.State {
 		Disabled: !element.Enabled(),
 		Focused:  element.entity.Focused(),
+		Invalid:  element.validationErr != nil,
 	}
 }
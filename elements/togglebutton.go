@@ -16,12 +16,14 @@ type ToggleButton struct {
 	enabled bool
 	pressed bool
 	on      bool
-	text    string
+
+	label labelText
 
 	showText bool
 	hasIcon  bool
 	iconId   tomo.Icon
-	
+
+	group    radioGroup
 	onToggle func ()
 }
 
@@ -107,6 +109,7 @@ func (element *ToggleButton) Draw (destination art.Canvas) {
 			offset = offset.Add(sink)
 		}
 		element.drawer.Draw(destination, foreground, offset)
+		element.label.underline(element.drawer, destination, foreground, offset)
 	}
 }
 
@@ -120,6 +123,14 @@ func (element *ToggleButton) Value () (on bool) {
 	return element.on
 }
 
+// SetValue sets whether or not the button is on. This does not affect other
+// buttons in its group, and does not call the OnToggle callback.
+func (element *ToggleButton) SetValue (on bool) {
+	if element.on == on { return }
+	element.on = on
+	element.entity.Invalidate()
+}
+
 // Focus gives this element input focus.
 func (element *ToggleButton) Focus () {
 	if !element.entity.Focused() { element.entity.Focus() }
@@ -139,9 +150,20 @@ func (element *ToggleButton) SetEnabled (enabled bool) {
 
 // SetText sets the button's label text.
 func (element *ToggleButton) SetText (text string) {
-	if element.text == text { return }
-	element.text = text
-	element.drawer.SetText([]rune(text))
+	element.setText(text, false)
+}
+
+// SetTextWithMnemonic is like SetText, but an underscore marks the character
+// after it as the mnemonic, which can be pressed along with Alt to activate the
+// button. Use two underscores to display a literal underscore.
+func (element *ToggleButton) SetTextWithMnemonic (text string) {
+	element.setText(text, true)
+}
+
+func (element *ToggleButton) setText (text string, parse bool) {
+	display, changed := element.label.set(text, parse)
+	if !changed { return }
+	element.drawer.SetText(display)
 	element.updateMinimumSize()
 	element.entity.Invalidate()
 }
@@ -176,6 +198,15 @@ func (element *ToggleButton) HandleThemeChange () {
 	element.entity.Invalidate()
 }
 
+func (element *ToggleButton) Mnemonic () rune {
+	return element.label.mnemonic
+}
+
+func (element *ToggleButton) HandleMnemonic () {
+	element.Focus()
+	element.toggle()
+}
+
 func (element *ToggleButton) HandleFocusChange () {
 	element.entity.Invalidate()
 }
@@ -201,10 +232,7 @@ func (element *ToggleButton) HandleMouseUp (
 	element.pressed = false
 	within := position.In(element.entity.Bounds())
 	if element.Enabled() && within {
-		element.on = !element.on
-		if element.onToggle != nil {
-			element.onToggle()
-		}
+		element.toggle()
 	}
 	element.entity.Invalidate()
 }
@@ -214,6 +242,8 @@ func (element *ToggleButton) HandleKeyDown (key input.Key, modifiers input.Modif
 	if key == input.KeyEnter {
 		element.pressed = true
 		element.entity.Invalidate()
+	} else if element.group != nil {
+		element.group.handleArrowKey(element, key)
 	}
 }
 
@@ -222,10 +252,31 @@ func (element *ToggleButton) HandleKeyUp(key input.Key, modifiers input.Modifier
 		element.pressed = false
 		element.entity.Invalidate()
 		if !element.Enabled() { return }
-		element.on = !element.on
-		if element.onToggle != nil {
-			element.onToggle()
-		}
+		element.toggle()
+	}
+}
+
+func (element *ToggleButton) setGroup (group radioGroup) (previous radioGroup) {
+	previous = element.group
+	element.group = group
+	return
+}
+
+func (element *ToggleButton) activate () {
+	if !element.on { element.toggle() }
+}
+
+// toggle toggles the button in response to user input. While the button is in
+// a group, it can only be turned on.
+func (element *ToggleButton) toggle () {
+	if element.group != nil && element.on { return }
+	element.on = !element.on
+	element.entity.Invalidate()
+	if element.on && element.group != nil {
+		element.group.handleSelect(element)
+	}
+	if element.onToggle != nil {
+		element.onToggle()
 	}
 }
 
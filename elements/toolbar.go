@@ -0,0 +1,518 @@
+package elements
+
+import "image"
+import "tomo"
+import "tomo/menu"
+import "tomo/input"
+import "art"
+import "tomo/textdraw"
+
+var toolbarCase          = tomo.C("tomo", "toolbar")
+var toolbarItemCase      = tomo.C("tomo", "toolbar", "item")
+var toolbarSeparatorCase = tomo.C("tomo", "toolbar", "separator")
+var toolbarOverflowCase  = tomo.C("tomo", "toolbar", "overflow")
+
+// ToolbarMode determines what is displayed on each item of a Toolbar.
+type ToolbarMode int; const (
+	// ToolbarIcons displays only the icon of each item. Items without an
+	// icon display their text instead.
+	ToolbarIcons ToolbarMode = iota
+
+	// ToolbarText displays only the text of each item.
+	ToolbarText
+
+	// ToolbarBoth displays the icon of each item next to its text.
+	ToolbarBoth
+)
+
+type toolbarRow struct {
+	drawer textdraw.Drawer
+}
+
+// Toolbar is a horizontal row of buttons, typically placed under a menu bar.
+// It is described using the same items as menus: action items become buttons,
+// check and radio items become toggle buttons, separators are drawn as lines,
+// and items with a submenu open it when clicked. When the toolbar is too narrow
+// to fit every item, the ones that don't fit are moved into a menu that can be
+// opened with a button at the end.
+type Toolbar struct {
+	entity tomo.Entity
+	items  menu.Menu
+	rows   []toolbarRow
+	mode   ToolbarMode
+
+	enabled  bool
+	pressed  int
+	selected int
+	open     *menuPane
+}
+
+// NewToolbar creates a new toolbar containing the specified items.
+func NewToolbar (items ...*menu.Item) (element *Toolbar) {
+	element = &Toolbar {
+		items:    items,
+		rows:     make([]toolbarRow, len(items)),
+		enabled:  true,
+		pressed:  -1,
+		selected: -1,
+	}
+	element.entity = tomo.GetBackend().NewEntity(element)
+	element.updateFaces()
+	for index, item := range items {
+		// toolbar items don't have mnemonics, but the text might still
+		// be shared with a menu that does
+		display, _, _ := textdraw.ParseMnemonic(item.Text)
+		element.rows[index].drawer.SetText(display)
+	}
+	element.updateMinimumSize()
+	return
+}
+
+// Entity returns this element's entity.
+func (element *Toolbar) Entity () tomo.Entity {
+	return element.entity
+}
+
+// Draw causes the element to draw to the specified destination canvas.
+func (element *Toolbar) Draw (destination art.Canvas) {
+	theme := element.entity.Theme()
+	theme.Pattern(tomo.PatternRaised, tomo.State { }, toolbarCase).
+		Draw(destination, element.entity.Bounds())
+
+	visible := element.visible()
+	for index := 0; index < visible; index ++ {
+		if element.items[index].Kind == menu.KindSeparator {
+			element.drawSeparator(destination, index)
+		} else {
+			element.drawItem(destination, index)
+		}
+	}
+
+	if visible < len(element.items) {
+		bounds := element.overflowBounds()
+		state  := tomo.State {
+			Disabled: !element.enabled,
+			Focused:  element.entity.Focused() && element.selected == visible,
+			Pressed:  element.open != nil,
+		}
+		theme.Pattern(tomo.PatternButton, state, toolbarOverflowCase).
+			Draw(destination, bounds)
+		icon := theme.Icon(tomo.IconForward, tomo.IconSizeSmall, toolbarOverflowCase)
+		if icon != nil {
+			iconBounds := icon.Bounds()
+			icon.Draw (
+				destination,
+				theme.Color(tomo.ColorForeground, state, toolbarOverflowCase),
+				bounds.Min.Add(image.Pt (
+					(bounds.Dx() - iconBounds.Dx()) / 2,
+					(bounds.Dy() - iconBounds.Dy()) / 2)))
+		}
+	}
+}
+
+// Menu returns the items in the toolbar.
+func (element *Toolbar) Menu () menu.Menu {
+	return element.items
+}
+
+// Update redraws the toolbar. This must be called after any of its items are
+// changed, for example when one is disabled.
+func (element *Toolbar) Update () {
+	for index, item := range element.items {
+		display, _, _ := textdraw.ParseMnemonic(item.Text)
+		element.rows[index].drawer.SetText(display)
+	}
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+}
+
+// SetMode sets what is displayed on each item.
+func (element *Toolbar) SetMode (mode ToolbarMode) {
+	if element.mode == mode { return }
+	element.mode = mode
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+}
+
+// Mode returns what is displayed on each item.
+func (element *Toolbar) Mode () ToolbarMode {
+	return element.mode
+}
+
+// RegisterAccelerators adds the accelerators of every item in the toolbar to
+// the accelerator table of the specified window. If any of them conflict with
+// accelerators already in the table, an error is returned and the table is left
+// unchanged.
+func (element *Toolbar) RegisterAccelerators (window tomo.Window) error {
+	table := window.Accelerators()
+	registered := 0
+	rollback := func (err error) error {
+		for _, item := range element.items[:registered] {
+			if item.Submenu != nil {
+				menu.UnregisterAccelerators(table, item.Submenu)
+			} else if item.Accelerator.Key != input.KeyNone {
+				table.Remove(item.Accelerator)
+			}
+		}
+		return err
+	}
+
+	for index, item := range element.items {
+		if item.Submenu != nil {
+			err := menu.RegisterAccelerators(table, item.Submenu)
+			if err != nil { return rollback(err) }
+			registered ++
+			continue
+		}
+		if item.Accelerator.Key != input.KeyNone {
+			index := index
+			err := table.Add(item.Accelerator, func () {
+				element.activate(index, false)
+			})
+			if err != nil { return rollback(err) }
+		}
+		registered ++
+	}
+	return nil
+}
+
+// Focus gives this element input focus.
+func (element *Toolbar) Focus () {
+	if !element.entity.Focused() { element.entity.Focus() }
+}
+
+// Enabled returns whether this toolbar is enabled or not.
+func (element *Toolbar) Enabled () bool {
+	return element.enabled
+}
+
+// SetEnabled sets whether this toolbar can be interacted with or not.
+func (element *Toolbar) SetEnabled (enabled bool) {
+	if element.enabled == enabled { return }
+	element.enabled = enabled
+	if !enabled && element.open != nil { element.open.close() }
+	element.entity.Invalidate()
+}
+
+func (element *Toolbar) HandleThemeChange () {
+	element.updateFaces()
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+}
+
+func (element *Toolbar) HandleFocusChange () {
+	if element.entity.Focused() && element.selected < 0 {
+		element.selected = element.next(-1, 1)
+	}
+	element.entity.Invalidate()
+}
+
+func (element *Toolbar) HandleMouseDown (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) {
+	if !element.enabled || button != input.ButtonLeft { return }
+	index := element.itemAt(position)
+	if index < 0 { return }
+	element.selected = index
+
+	// menus open right away, but buttons wait until they are released
+	if index >= element.visible() || element.items[index].Submenu != nil {
+		element.activate(index, false)
+		return
+	}
+	element.pressed = index
+	element.entity.Invalidate()
+}
+
+func (element *Toolbar) HandleMouseUp (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) {
+	if button != input.ButtonLeft || element.pressed < 0 { return }
+	index := element.pressed
+	element.pressed = -1
+	element.entity.Invalidate()
+	if element.itemAt(position) == index {
+		element.activate(index, false)
+	}
+}
+
+func (element *Toolbar) HandleKeyDown (key input.Key, modifiers input.Modifiers) {
+	if !element.enabled { return }
+	switch key {
+	case input.KeyLeft:
+		element.selected = element.next(element.selected, -1)
+		element.entity.Invalidate()
+	case input.KeyRight:
+		element.selected = element.next(element.selected, 1)
+		element.entity.Invalidate()
+	case input.KeyEnter, ' ':
+		if element.selected >= 0 {
+			element.activate(element.selected, true)
+		}
+	case input.KeyDown:
+		if element.selected >= element.visible() ||
+			element.selected >= 0 &&
+			element.items[element.selected].Submenu != nil {
+			element.activate(element.selected, true)
+		}
+	}
+}
+
+func (element *Toolbar) HandleKeyUp (key input.Key, modifiers input.Modifiers) { }
+
+// activate activates the item at the specified index. If the index is that of
+// the first item that doesn't fit, the overflow menu is opened instead.
+func (element *Toolbar) activate (index int, keyboard bool) {
+	if !element.enabled { return }
+	if element.open != nil { element.open.close() }
+
+	visible := element.visible()
+	var items menu.Menu
+	var origin image.Rectangle
+	if index >= visible {
+		items  = element.items[visible:]
+		origin = element.overflowBounds()
+	} else {
+		item := element.items[index]
+		if !item.Activatable() { return }
+		if item.Submenu == nil {
+			element.items.Activate(index)
+			element.entity.Invalidate()
+			return
+		}
+		items  = item.Submenu
+		origin = element.itemBounds(index)
+	}
+
+	window := element.entity.Window()
+	if window == nil { return }
+	pane, err := openMenuPane (
+		window,
+		image.Pt(origin.Min.X, origin.Max.Y),
+		items, nil)
+	if err != nil { return }
+	pane.onClose = func () {
+		if element.open != pane { return }
+		element.open = nil
+		element.entity.Invalidate()
+	}
+	element.open = pane
+	element.entity.Invalidate()
+	if keyboard { pane.step(1) }
+}
+
+// next returns the index of the next activatable item after index in the
+// specified direction, wrapping around if necessary. The overflow button is
+// treated as an item after the last visible one. If there is none, it returns
+// -1.
+func (element *Toolbar) next (index, direction int) int {
+	visible := element.visible()
+	count := visible
+	if visible < len(element.items) { count ++ }
+	for step := 0; step < count; step ++ {
+		index += direction
+		if index < 0      { index = count - 1 }
+		if index >= count { index = 0 }
+		if index >= visible || element.items[index].Activatable() {
+			return index
+		}
+	}
+	return -1
+}
+
+func (element *Toolbar) drawItem (destination art.Canvas, index int) {
+	theme := element.entity.Theme()
+	item  := element.items[index]
+	bounds := element.itemBounds(index)
+	state := tomo.State {
+		Disabled: item.Disabled || !element.enabled,
+		Focused:  element.entity.Focused() && element.selected == index,
+		Pressed:  element.pressed == index,
+		On:       item.Checked,
+	}
+	theme.Pattern(tomo.PatternButton, state, toolbarItemCase).Draw(destination, bounds)
+
+	foreground := theme.Color(tomo.ColorForeground, state, toolbarItemCase)
+	padding    := theme.Padding(tomo.PatternButton, toolbarItemCase)
+	margin     := theme.Margin(tomo.PatternButton, toolbarItemCase)
+	inner := padding.Apply(bounds)
+	if state.Pressed {
+		inner = inner.Add(theme.Sink(tomo.PatternButton, toolbarItemCase))
+	}
+	showIcon, showText := element.shows(index)
+	x := inner.Min.X
+
+	if showIcon {
+		icon := theme.Icon(item.Icon, tomo.IconSizeSmall, toolbarItemCase)
+		iconBounds := icon.Bounds()
+		if !showText {
+			x += (inner.Dx() - iconBounds.Dx()) / 2
+		}
+		icon.Draw(destination, foreground, image.Pt (
+			x, inner.Min.Y + (inner.Dy() - iconBounds.Dy()) / 2))
+		x += iconBounds.Dx() + margin.X
+	}
+
+	if showText {
+		drawer := &element.rows[index].drawer
+		textBounds := drawer.LayoutBounds()
+		offset := image.Pt (
+			x,
+			inner.Min.Y + (inner.Dy() - textBounds.Dy()) / 2).Sub(textBounds.Min)
+		drawer.Draw(destination, foreground, offset)
+	}
+}
+
+func (element *Toolbar) drawSeparator (destination art.Canvas, index int) {
+	theme  := element.entity.Theme()
+	bounds := element.itemBounds(index)
+	width  := theme.Padding(tomo.PatternLine, toolbarSeparatorCase).Horizontal()
+	line := image.Rect (
+		bounds.Min.X + (bounds.Dx() - width) / 2, bounds.Min.Y,
+		bounds.Min.X + (bounds.Dx() - width) / 2 + width, bounds.Max.Y)
+	theme.Pattern(tomo.PatternLine, tomo.State { }, toolbarSeparatorCase).
+		Draw(destination, line)
+}
+
+// shows returns whether the item at the specified index displays its icon,
+// its text, or both.
+func (element *Toolbar) shows (index int) (icon, text bool) {
+	item := element.items[index]
+	if item.Icon != tomo.IconNone {
+		icon = element.entity.Theme().Icon (
+			item.Icon, tomo.IconSizeSmall,
+			toolbarItemCase) != nil
+	}
+	switch element.mode {
+	case ToolbarText:  return false, true
+	case ToolbarBoth:  return icon, true
+	default:           return icon, !icon
+	}
+}
+
+func (element *Toolbar) itemWidth (index int) int {
+	theme := element.entity.Theme()
+	if element.items[index].Kind == menu.KindSeparator {
+		margin := theme.Margin(tomo.PatternLine, toolbarSeparatorCase)
+		return theme.Padding(tomo.PatternLine, toolbarSeparatorCase).Horizontal() +
+			margin.X
+	}
+
+	padding := theme.Padding(tomo.PatternButton, toolbarItemCase)
+	margin  := theme.Margin(tomo.PatternButton, toolbarItemCase)
+	showIcon, showText := element.shows(index)
+	width := 0
+	if showIcon {
+		icon := theme.Icon(element.items[index].Icon, tomo.IconSizeSmall, toolbarItemCase)
+		width += icon.Bounds().Dx()
+	}
+	if showText {
+		if showIcon { width += margin.X }
+		width += element.rows[index].drawer.LayoutBounds().Dx()
+	}
+	return width + padding.Horizontal()
+}
+
+func (element *Toolbar) itemHeight () int {
+	padding := element.entity.Theme().Padding(tomo.PatternButton, toolbarItemCase)
+	height := 0
+	for index := range element.items {
+		showIcon, showText := element.shows(index)
+		if showIcon {
+			icon := element.entity.Theme().Icon (
+				element.items[index].Icon, tomo.IconSizeSmall,
+				toolbarItemCase)
+			if icon.Bounds().Dy() > height { height = icon.Bounds().Dy() }
+		}
+		if showText {
+			lineHeight := element.rows[index].drawer.LineHeight().Round()
+			if lineHeight > height { height = lineHeight }
+		}
+	}
+	return height + padding.Vertical()
+}
+
+func (element *Toolbar) inner () image.Rectangle {
+	padding := element.entity.Theme().Padding(tomo.PatternRaised, toolbarCase)
+	return padding.Apply(element.entity.Bounds())
+}
+
+// visible returns how many items fit in the toolbar. The rest are placed in
+// the overflow menu.
+func (element *Toolbar) visible () int {
+	available := element.inner().Dx()
+	total := 0
+	for index := range element.items {
+		total += element.itemWidth(index)
+	}
+	if total <= available { return len(element.items) }
+
+	// make room for the overflow button
+	available -= element.itemHeight()
+	total = 0
+	for index := range element.items {
+		total += element.itemWidth(index)
+		if total > available { return index }
+	}
+	return len(element.items)
+}
+
+func (element *Toolbar) itemBounds (index int) image.Rectangle {
+	inner := element.inner()
+	x := inner.Min.X
+	for current := 0; current < index; current ++ {
+		x += element.itemWidth(current)
+	}
+	return image.Rect(x, inner.Min.Y, x + element.itemWidth(index), inner.Max.Y)
+}
+
+func (element *Toolbar) overflowBounds () image.Rectangle {
+	inner := element.inner()
+	size  := element.itemHeight()
+	return image.Rect(inner.Max.X - size, inner.Min.Y, inner.Max.X, inner.Max.Y)
+}
+
+// itemAt returns the index of the activatable item under the specified
+// position. If it is over the overflow button, the index of the first item
+// that doesn't fit is returned.
+func (element *Toolbar) itemAt (position image.Point) int {
+	visible := element.visible()
+	if visible < len(element.items) && position.In(element.overflowBounds()) {
+		return visible
+	}
+	for index := 0; index < visible; index ++ {
+		if position.In(element.itemBounds(index)) {
+			if !element.items[index].Activatable() { return -1 }
+			return index
+		}
+	}
+	return -1
+}
+
+func (element *Toolbar) updateFaces () {
+	face := element.entity.Theme().FontFace (
+		tomo.FontStyleRegular,
+		tomo.FontSizeNormal,
+		toolbarItemCase)
+	for index := range element.rows {
+		element.rows[index].drawer.SetFace(face)
+	}
+}
+
+func (element *Toolbar) updateMinimumSize () {
+	// the toolbar only has to be wide enough for the overflow button, and
+	// one item if it has any
+	padding := element.entity.Theme().Padding(tomo.PatternRaised, toolbarCase)
+	height  := element.itemHeight()
+	width   := height
+	if len(element.items) > 0 {
+		width += element.itemWidth(0)
+	}
+	element.entity.SetMinimumSize (
+		width  + padding.Horizontal(),
+		height + padding.Vertical())
+}
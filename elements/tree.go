@@ -0,0 +1,818 @@
+package elements
+
+import "time"
+import "image"
+import "golang.org/x/image/font"
+import "tomo"
+import "tomo/input"
+import "art"
+import "tomo/textdraw"
+
+var treeCase    = tomo.C("tomo", "tree")
+var treeRowCase = tomo.C("tomo", "tree", "row")
+
+// TreeNode is a value that identifies a node within a TreeModel. Nodes are
+// compared with ==, so they must be of a comparable type such as a string, a
+// number, or a pointer.
+type TreeNode interface { }
+
+// TreeModel provides the data displayed by a Tree. The root of the tree is
+// represented by a nil node, and is not displayed itself.
+type TreeModel interface {
+	// Children returns the children of the specified node. This is only
+	// called when the node is first expanded, or when it is refreshed, so
+	// children can be loaded lazily.
+	Children (node TreeNode) []TreeNode
+
+	// Expandable returns whether the specified node can be expanded. This
+	// is called for every node that is loaded, so it should be cheap.
+	Expandable (node TreeNode) bool
+
+	// Text returns the text displayed for the specified node.
+	Text (node TreeNode) string
+
+	// Icon returns the icon displayed next to the specified node. If it
+	// returns tomo.IconNone, no icon is displayed.
+	Icon (node TreeNode) tomo.Icon
+}
+
+type treeItem struct {
+	node       TreeNode
+	parent     *treeItem
+	children   []*treeItem
+	depth      int
+	loaded     bool
+	expanded   bool
+	expandable bool
+	selected   bool
+	icon       tomo.Icon
+	drawer     textdraw.Drawer
+}
+
+// within returns whether the item is a descendant of ancestor.
+func (item *treeItem) within (ancestor *treeItem) bool {
+	for current := item.parent; current != nil; current = current.parent {
+		if current == ancestor { return true }
+	}
+	return false
+}
+
+// walk calls a function for every loaded descendant of the item.
+func (item *treeItem) walk (callback func (*treeItem)) {
+	for _, child := range item.children {
+		callback(child)
+		child.walk(callback)
+	}
+}
+
+// Tree displays hierarchical data from a TreeModel as a list of rows that can
+// be expanded and collapsed. It is meant to be placed inside of a Scroll.
+type Tree struct {
+	entity tomo.Entity
+	model  TreeModel
+	root   *treeItem
+	rows   []*treeItem
+
+	selector selector
+	enabled  bool
+	cursor   *treeItem
+	anchor   *treeItem
+
+	scroll        image.Point
+	contentBounds image.Rectangle
+
+	forcedMinimumWidth  int
+	forcedMinimumHeight int
+
+	lastClick   time.Time
+	lastClicked *treeItem
+
+	onActivate           func (node TreeNode)
+	onScrollBoundsChange func ()
+}
+
+// NewTree creates a new tree displaying data from the specified model. Only the
+// top level of the tree is loaded at first.
+func NewTree (model TreeModel) (element *Tree) {
+	element = &Tree { enabled: true }
+	element.entity = tomo.GetBackend().NewEntity(element)
+	element.selector.items = element
+	element.SetModel(model)
+	return
+}
+
+// Entity returns this element's entity.
+func (element *Tree) Entity () tomo.Entity {
+	return element.entity
+}
+
+// Draw causes the element to draw to the specified destination canvas.
+func (element *Tree) Draw (destination art.Canvas) {
+	element.entity.Theme().Pattern(tomo.PatternSunken, element.state(), treeCase).
+		Draw(destination, element.entity.Bounds())
+
+	if len(element.rows) == 0 { return }
+	inner  := element.inner()
+	canvas := art.Cut(destination, inner)
+	height := element.rowHeight()
+	first  := element.scroll.Y / height
+	last   := (element.scroll.Y + inner.Dy()) / height
+	for index := first; index <= last && index < len(element.rows); index ++ {
+		element.drawRow(canvas, index)
+	}
+}
+
+func (element *Tree) drawRow (destination art.Canvas, index int) {
+	row    := element.rows[index]
+	bounds := element.rowBounds(index)
+	theme  := element.entity.Theme()
+
+	state := tomo.State {
+		Disabled: !element.enabled,
+		Focused:  element.entity.Focused() && row == element.cursor,
+		On:       row.selected,
+	}
+	if state.On || state.Focused {
+		theme.Pattern(tomo.PatternTableCell, state, treeRowCase).
+			Draw(destination, bounds)
+	}
+
+	foreground := theme.Color(tomo.ColorForeground, state, treeRowCase)
+	padding    := theme.Padding(tomo.PatternTableCell, treeRowCase)
+	margin     := theme.Margin(tomo.PatternTableCell, treeRowCase)
+	inner      := padding.Apply(bounds)
+	slot       := element.slot()
+	indent     := slot + margin.X
+
+	// indentation guides, one for each ancestor, running down through the
+	// middle of its expander
+	line := theme.Padding(tomo.PatternLine, treeCase)
+	guide := theme.Pattern (
+		tomo.PatternLine,
+		tomo.State { Disabled: !element.enabled }, treeCase)
+	for level := 0; level < row.depth; level ++ {
+		middle := inner.Min.X + level * indent + slot / 2
+		guide.Draw(destination, image.Rect (
+			middle - line[3], bounds.Min.Y,
+			middle + line[1], bounds.Max.Y))
+	}
+
+	drawIcon := func (icon art.Icon, x int) {
+		iconBounds := icon.Bounds()
+		icon.Draw(destination, foreground, image.Pt (
+			x, inner.Min.Y + (inner.Dy() - iconBounds.Dy()) / 2))
+	}
+
+	x := inner.Min.X + row.depth * indent
+	if row.expandable {
+		id := tomo.IconForward
+		if row.expanded { id = tomo.IconExpand }
+		if icon := theme.Icon(id, tomo.IconSizeSmall, treeRowCase); icon != nil {
+			drawIcon(icon, x)
+		}
+	}
+	x += indent
+
+	if row.icon != tomo.IconNone {
+		if icon := theme.Icon(row.icon, tomo.IconSizeSmall, treeRowCase); icon != nil {
+			drawIcon(icon, x)
+			x += icon.Bounds().Dx() + margin.X
+		}
+	}
+
+	textBounds := row.drawer.LayoutBounds()
+	offset := image.Pt (
+		x,
+		inner.Min.Y + (inner.Dy() - textBounds.Dy()) / 2).Sub(textBounds.Min)
+	row.drawer.Draw(destination, foreground, offset)
+}
+
+// Layout causes this element to perform a layout operation.
+func (element *Tree) Layout () {
+	maxScrollHeight := element.maxScrollHeight()
+	if element.scroll.Y > maxScrollHeight {
+		element.scroll.Y = maxScrollHeight
+	}
+	element.entity.NotifyScrollBoundsChange()
+	if element.onScrollBoundsChange != nil {
+		element.onScrollBoundsChange()
+	}
+}
+
+// SetModel sets the model that the tree gets its data from. All nodes are
+// collapsed and the selection is cleared.
+func (element *Tree) SetModel (model TreeModel) {
+	element.model  = model
+	element.root   = &treeItem { depth: -1, expanded: true }
+	element.cursor = nil
+	element.anchor = nil
+	element.lastClicked = nil
+	element.scroll = image.Point { }
+	if model != nil {
+		element.load(element.root)
+	}
+	element.flatten()
+	element.changed()
+}
+
+// Model returns the model that the tree gets its data from.
+func (element *Tree) Model () TreeModel {
+	return element.model
+}
+
+// Refresh reloads the specified node from the model, along with its children
+// if they have been loaded. Children that are still present keep their state,
+// while new ones start out collapsed. Passing nil reloads the top level of the
+// tree.
+func (element *Tree) Refresh (node TreeNode) {
+	if element.model == nil { return }
+	item := element.root
+	if node != nil {
+		item = element.find(node)
+		if item == nil { return }
+		element.describe(item)
+	}
+
+	selectionChanged := false
+	if item != element.root && !item.expandable && item.expanded {
+		selectionChanged = element.collapse(item)
+	}
+
+	if item.loaded {
+		old := item.children
+		item.children = nil
+		for _, node := range element.model.Children(item.node) {
+			var child *treeItem
+			for index, existing := range old {
+				if existing == nil || existing.node != node { continue }
+				child = existing
+				old[index] = nil
+				break
+			}
+			if child == nil {
+				child = element.newItem(node, item)
+			}
+			item.children = append(item.children, child)
+		}
+
+		// whatever is left over has been removed from the model
+		for _, removed := range old {
+			if removed == nil { continue }
+			if element.forget(removed, item) { selectionChanged = true }
+		}
+	}
+
+	element.flatten()
+	element.changed()
+	if selectionChanged && element.selector.onSelectionChange != nil {
+		element.selector.onSelectionChange()
+	}
+}
+
+// Expanded returns whether the specified node is expanded.
+func (element *Tree) Expanded (node TreeNode) bool {
+	item := element.find(node)
+	return item != nil && item.expanded
+}
+
+// SetExpanded expands or collapses the specified node. Only nodes that have
+// been loaded can be expanded, which means that their parent must have been
+// expanded at some point. When a node is collapsed, any of its descendants
+// that were selected are deselected.
+func (element *Tree) SetExpanded (node TreeNode, expanded bool) {
+	item := element.find(node)
+	if item == nil { return }
+	element.setExpanded(item, expanded)
+}
+
+// SelectionMode returns how many nodes can be selected at once.
+func (element *Tree) SelectionMode () SelectionMode {
+	return element.selector.mode
+}
+
+// SetSelectionMode sets how many nodes can be selected at once. By default,
+// only one node can be selected.
+func (element *Tree) SetSelectionMode (mode SelectionMode) {
+	element.selector.setMode(mode)
+}
+
+// Selected returns the selected nodes, in the order they are displayed.
+func (element *Tree) Selected () (nodes []TreeNode) {
+	for _, row := range element.rows {
+		if row.selected { nodes = append(nodes, row.node) }
+	}
+	return
+}
+
+// Select selects the specified nodes, deselecting all others. Nodes that are
+// not currently displayed are ignored. If only one node can be selected at a
+// time, only the first is used. Calling Select with no nodes clears the
+// selection.
+func (element *Tree) Select (nodes ...TreeNode) {
+	if element.selector.mode == SelectionSingle && len(nodes) > 1 {
+		nodes = nodes[:1]
+	}
+	items := make(map[*treeItem] bool)
+	for _, node := range nodes {
+		index := element.nodeRow(node)
+		if index < 0 { continue }
+		row := element.rows[index]
+		items[row] = true
+		element.cursor = row
+		element.anchor = row
+	}
+	element.selector.setSelection (func (index int) bool {
+		return items[element.rows[index]]
+	})
+	element.scrollToCursor()
+}
+
+// OnSelectionChange sets a function to be called when nodes are selected or
+// deselected.
+func (element *Tree) OnSelectionChange (callback func ()) {
+	element.selector.onSelectionChange = callback
+}
+
+// OnActivate sets a function to be called when a node is double-clicked, or
+// when Enter is pressed while it has the cursor.
+func (element *Tree) OnActivate (callback func (node TreeNode)) {
+	element.onActivate = callback
+}
+
+// Focus gives this element input focus.
+func (element *Tree) Focus () {
+	if !element.entity.Focused() { element.entity.Focus() }
+}
+
+// Enabled returns whether this tree is enabled or not.
+func (element *Tree) Enabled () bool {
+	return element.enabled
+}
+
+// SetEnabled sets whether this tree can be interacted with or not.
+func (element *Tree) SetEnabled (enabled bool) {
+	if element.enabled == enabled { return }
+	element.enabled = enabled
+	element.entity.Invalidate()
+}
+
+func (element *Tree) HandleFocusChange () {
+	element.entity.Invalidate()
+}
+
+func (element *Tree) HandleMouseDown (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) {
+	if !element.enabled { return }
+	element.Focus()
+	if button != input.ButtonLeft { return }
+
+	index := element.rowAt(position)
+	if index < 0 {
+		if !modifiers.Control && !modifiers.Shift {
+			element.selector.selectNone()
+		}
+		return
+	}
+
+	row := element.rows[index]
+	if row.expandable && position.In(element.expanderBounds(index)) {
+		element.setExpanded(row, !row.expanded)
+		return
+	}
+
+	element.pick(index, modifiers, false)
+	delay := element.entity.Config().DoubleClickDelay()
+	if row == element.lastClicked && time.Since(element.lastClick) < delay {
+		element.lastClicked = nil
+		element.activate(row)
+	} else {
+		element.lastClicked = row
+		element.lastClick   = time.Now()
+	}
+}
+
+func (element *Tree) HandleMouseUp (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) { }
+
+func (element *Tree) HandleKeyDown (key input.Key, modifiers input.Modifiers) {
+	if !element.enabled || len(element.rows) == 0 { return }
+	index := element.rowIndex(element.cursor)
+	row   := element.cursor
+	last  := len(element.rows) - 1
+
+	// with no cursor, any movement starts at the top
+	if row == nil {
+		switch key {
+		case
+			input.KeyUp, input.KeyDown, input.KeyLeft, input.KeyRight,
+			input.KeyPageUp, input.KeyPageDown, input.KeyHome, input.KeyEnd:
+
+			element.pick(0, modifiers, true)
+		}
+		return
+	}
+
+	switch key {
+	case input.KeyUp:
+		element.pick(index - 1, modifiers, true)
+	case input.KeyDown:
+		element.pick(index + 1, modifiers, true)
+	case input.KeyPageUp:
+		index -= element.pageRows()
+		if index < 0 { index = 0 }
+		element.pick(index, modifiers, true)
+	case input.KeyPageDown:
+		index += element.pageRows()
+		if index > last { index = last }
+		element.pick(index, modifiers, true)
+	case input.KeyHome:
+		element.pick(0, modifiers, true)
+	case input.KeyEnd:
+		element.pick(last, modifiers, true)
+	case input.KeyRight:
+		if row.expandable && !row.expanded {
+			element.setExpanded(row, true)
+		} else if row.expanded && len(row.children) > 0 {
+			element.pick(index + 1, modifiers, true)
+		}
+	case input.KeyLeft:
+		if row.expanded {
+			element.setExpanded(row, false)
+		} else if row.parent != element.root {
+			element.pick(element.rowIndex(row.parent), modifiers, true)
+		}
+	case ' ':
+		element.pick(index, modifiers, false)
+	case input.KeyEnter:
+		element.activate(row)
+	}
+}
+
+func (element *Tree) HandleKeyUp (key input.Key, modifiers input.Modifiers) { }
+
+func (element *Tree) HandleThemeChange () {
+	face := element.face()
+	element.root.walk (func (item *treeItem) {
+		item.drawer.SetFace(face)
+	})
+	element.changed()
+}
+
+// Collapse forces a minimum width and height upon the tree. If a zero value is
+// given for a dimension, its minimum will be determined by the tree's content.
+// If the tree's height goes beyond the forced size, it will need to be accessed
+// via scrolling.
+func (element *Tree) Collapse (width, height int) {
+	if
+		element.forcedMinimumWidth == width &&
+		element.forcedMinimumHeight == height {
+
+		return
+	}
+
+	element.forcedMinimumWidth  = width
+	element.forcedMinimumHeight = height
+	element.changed()
+}
+
+// ScrollContentBounds returns the full content size of the element.
+func (element *Tree) ScrollContentBounds () image.Rectangle {
+	return element.contentBounds
+}
+
+// ScrollViewportBounds returns the size and position of the element's
+// viewport relative to ScrollBounds.
+func (element *Tree) ScrollViewportBounds () image.Rectangle {
+	bounds := element.inner()
+	return bounds.Sub(bounds.Min).Add(element.scroll)
+}
+
+// ScrollTo scrolls the viewport to the specified point relative to
+// ScrollBounds.
+func (element *Tree) ScrollTo (position image.Point) {
+	position.X = 0
+	if position.Y < 0 {
+		position.Y = 0
+	}
+	maxScrollHeight := element.maxScrollHeight()
+	if position.Y > maxScrollHeight {
+		position.Y = maxScrollHeight
+	}
+	element.scroll = position
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+// ScrollAxes returns the supported axes for scrolling.
+func (element *Tree) ScrollAxes () (horizontal, vertical bool) {
+	return false, true
+}
+
+// OnScrollBoundsChange sets a function to be called when the element's viewport
+// bounds, content bounds, or scroll axes change.
+func (element *Tree) OnScrollBoundsChange (callback func ()) {
+	element.onScrollBoundsChange = callback
+}
+
+func (element *Tree) newItem (node TreeNode, parent *treeItem) *treeItem {
+	item := &treeItem {
+		node:   node,
+		parent: parent,
+		depth:  parent.depth + 1,
+	}
+	item.drawer.SetFace(element.face())
+	element.describe(item)
+	return item
+}
+
+// describe fetches the information displayed for an item from the model.
+func (element *Tree) describe (item *treeItem) {
+	item.expandable = element.model.Expandable(item.node)
+	item.icon       = element.model.Icon(item.node)
+	item.drawer.SetText([]rune(element.model.Text(item.node)))
+}
+
+// load fetches the children of an item from the model.
+func (element *Tree) load (item *treeItem) {
+	item.loaded   = true
+	item.children = nil
+	for _, node := range element.model.Children(item.node) {
+		item.children = append(item.children, element.newItem(node, item))
+	}
+}
+
+// flatten rebuilds the list of rows that are displayed from the items that are
+// expanded.
+func (element *Tree) flatten () {
+	element.rows = nil
+	var visit func (item *treeItem)
+	visit = func (item *treeItem) {
+		for _, child := range item.children {
+			element.rows = append(element.rows, child)
+			if child.expanded { visit(child) }
+		}
+	}
+	visit(element.root)
+}
+
+func (element *Tree) setExpanded (item *treeItem, expanded bool) {
+	if item.expanded == expanded || !item.expandable { return }
+
+	selectionChanged := false
+	if expanded {
+		item.expanded = true
+		if !item.loaded { element.load(item) }
+	} else {
+		selectionChanged = element.collapse(item)
+	}
+
+	element.flatten()
+	element.changed()
+	element.scrollToCursor()
+	if selectionChanged && element.selector.onSelectionChange != nil {
+		element.selector.onSelectionChange()
+	}
+}
+
+// collapse collapses an item, deselecting any of its descendants and moving the
+// cursor out of them. It returns whether the selection changed.
+func (element *Tree) collapse (item *treeItem) (selectionChanged bool) {
+	item.expanded = false
+	item.walk (func (descendant *treeItem) {
+		if descendant.selected {
+			descendant.selected = false
+			selectionChanged = true
+		}
+	})
+	if element.cursor != nil && element.cursor.within(item) {
+		element.cursor = item
+	}
+	if element.anchor != nil && element.anchor.within(item) {
+		element.anchor = item
+	}
+	return
+}
+
+// forget cleans up after an item that has been removed from parent. It returns
+// whether the selection changed.
+func (element *Tree) forget (item, parent *treeItem) (selectionChanged bool) {
+	gone := func (current *treeItem) bool {
+		return current != nil && (current == item || current.within(item))
+	}
+	if parent == element.root { parent = nil }
+	if gone(element.cursor)      { element.cursor      = parent }
+	if gone(element.anchor)      { element.anchor      = parent }
+	if gone(element.lastClicked) { element.lastClicked = nil }
+
+	selectionChanged = item.selected
+	item.walk (func (descendant *treeItem) {
+		if descendant.selected { selectionChanged = true }
+	})
+	return
+}
+
+// pick moves the cursor to the row at the specified index, and changes the
+// selection depending on which modifier keys are held down.
+func (element *Tree) pick (index int, modifiers input.Modifiers, keyboard bool) {
+	element.selector.pick(index, modifiers, keyboard)
+	element.entity.Invalidate()
+	element.scrollToCursor()
+}
+
+func (element *Tree) itemCount () int {
+	return len(element.rows)
+}
+
+func (element *Tree) itemSelected (index int) bool {
+	return element.rows[index].selected
+}
+
+func (element *Tree) selectItem (index int, selected bool) (changed bool) {
+	row := element.rows[index]
+	if row.selected == selected { return false }
+	row.selected = selected
+	element.entity.Invalidate()
+	return true
+}
+
+func (element *Tree) cursorIndex () int {
+	return element.rowIndex(element.cursor)
+}
+
+func (element *Tree) setCursorIndex (index int) {
+	element.cursor = element.rows[index]
+}
+
+func (element *Tree) anchorIndex () int {
+	return element.rowIndex(element.anchor)
+}
+
+func (element *Tree) setAnchorIndex (index int) {
+	element.anchor = element.rows[index]
+}
+
+func (element *Tree) activate (row *treeItem) {
+	if element.onActivate != nil {
+		element.onActivate(row.node)
+	}
+}
+
+func (element *Tree) scrollToCursor () {
+	index := element.rowIndex(element.cursor)
+	if index < 0 { return }
+	target := element.rowBounds(index)
+	bounds := element.inner()
+	if target.Min.Y < bounds.Min.Y {
+		element.ScrollTo(element.scroll.Sub(image.Pt(0, bounds.Min.Y - target.Min.Y)))
+	} else if target.Max.Y > bounds.Max.Y {
+		element.ScrollTo(element.scroll.Add(image.Pt(0, target.Max.Y - bounds.Max.Y)))
+	}
+}
+
+// find searches every loaded item for the specified node.
+func (element *Tree) find (node TreeNode) (found *treeItem) {
+	element.root.walk (func (item *treeItem) {
+		if found == nil && item.node == node { found = item }
+	})
+	return
+}
+
+func (element *Tree) nodeRow (node TreeNode) int {
+	for index, row := range element.rows {
+		if row.node == node { return index }
+	}
+	return -1
+}
+
+func (element *Tree) rowIndex (item *treeItem) int {
+	if item == nil { return -1 }
+	for index, row := range element.rows {
+		if row == item { return index }
+	}
+	return -1
+}
+
+func (element *Tree) rowAt (position image.Point) int {
+	if !position.In(element.inner()) { return -1 }
+	index := (position.Y - element.inner().Min.Y + element.scroll.Y) / element.rowHeight()
+	if index >= len(element.rows) { return -1 }
+	return index
+}
+
+func (element *Tree) rowBounds (index int) image.Rectangle {
+	inner  := element.inner()
+	height := element.rowHeight()
+	y := inner.Min.Y + index * height - element.scroll.Y
+	return image.Rect(inner.Min.X, y, inner.Max.X, y + height)
+}
+
+// expanderBounds returns the area of a row that expands or collapses it when
+// clicked.
+func (element *Tree) expanderBounds (index int) image.Rectangle {
+	theme   := element.entity.Theme()
+	padding := theme.Padding(tomo.PatternTableCell, treeRowCase)
+	margin  := theme.Margin(tomo.PatternTableCell, treeRowCase)
+	bounds  := element.rowBounds(index)
+	slot    := element.slot()
+	x := padding.Apply(bounds).Min.X + element.rows[index].depth * (slot + margin.X)
+	return image.Rect(x, bounds.Min.Y, x + slot, bounds.Max.Y)
+}
+
+// slot returns the width of the space taken up by the expander of each row,
+// which is also how far each level of the tree is indented.
+func (element *Tree) slot () int {
+	icon := element.entity.Theme().Icon(tomo.IconExpand, tomo.IconSizeSmall, treeRowCase)
+	if icon == nil { return element.face().Metrics().Height.Ceil() }
+	return icon.Bounds().Dx()
+}
+
+func (element *Tree) rowHeight () int {
+	theme   := element.entity.Theme()
+	padding := theme.Padding(tomo.PatternTableCell, treeRowCase)
+	height  := element.face().Metrics().Height.Ceil()
+	if icon := theme.Icon(tomo.IconExpand, tomo.IconSizeSmall, treeRowCase); icon != nil {
+		if icon.Bounds().Dy() > height { height = icon.Bounds().Dy() }
+	}
+	return height + padding.Vertical()
+}
+
+func (element *Tree) rowWidth (index int) int {
+	theme   := element.entity.Theme()
+	padding := theme.Padding(tomo.PatternTableCell, treeRowCase)
+	margin  := theme.Margin(tomo.PatternTableCell, treeRowCase)
+	row     := element.rows[index]
+
+	width := (row.depth + 1) * (element.slot() + margin.X)
+	if row.icon != tomo.IconNone {
+		if icon := theme.Icon(row.icon, tomo.IconSizeSmall, treeRowCase); icon != nil {
+			width += icon.Bounds().Dx() + margin.X
+		}
+	}
+	return width + row.drawer.LayoutBounds().Dx() + padding.Horizontal()
+}
+
+func (element *Tree) pageRows () int {
+	rows := element.inner().Dy() / element.rowHeight()
+	if rows < 1 { rows = 1 }
+	return rows
+}
+
+func (element *Tree) inner () image.Rectangle {
+	padding := element.entity.Theme().Padding(tomo.PatternSunken, treeCase)
+	return padding.Apply(element.entity.Bounds())
+}
+
+func (element *Tree) maxScrollHeight () (height int) {
+	height = element.contentBounds.Dy() - element.inner().Dy()
+	if height < 0 { height = 0 }
+	return
+}
+
+func (element *Tree) face () font.Face {
+	return element.entity.Theme().FontFace (
+		tomo.FontStyleRegular,
+		tomo.FontSizeNormal,
+		treeRowCase)
+}
+
+func (element *Tree) state () tomo.State {
+	return tomo.State {
+		Focused:  element.entity.Focused(),
+		Disabled: !element.enabled,
+	}
+}
+
+func (element *Tree) changed () {
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+func (element *Tree) updateMinimumSize () {
+	padding := element.entity.Theme().Padding(tomo.PatternSunken, treeCase)
+
+	width  := 0
+	height := len(element.rows) * element.rowHeight()
+	for index := range element.rows {
+		if rowWidth := element.rowWidth(index); rowWidth > width {
+			width = rowWidth
+		}
+	}
+	element.contentBounds = image.Rect(0, 0, width, height)
+
+	width  += padding.Horizontal()
+	height += padding.Vertical()
+	if element.forcedMinimumWidth > 0 {
+		width = element.forcedMinimumWidth
+	}
+	if element.forcedMinimumHeight > 0 {
+		height = element.forcedMinimumHeight
+	}
+	element.entity.SetMinimumSize(width, height)
+}
@@ -0,0 +1,653 @@
+package elements
+
+import "time"
+import "image"
+import "tomo"
+import "tomo/input"
+import "art"
+import "art/artutil"
+
+var virtualListCase = tomo.C("tomo", "list")
+
+// RowFactory is a function that returns an element displaying the row of a
+// VirtualList at the specified index. If recycled is not nil, it is an element
+// that was previously returned by the factory but is no longer being displayed,
+// and it should be updated to display the row and returned instead of a new
+// element being created. Rows should satisfy ability.Selectable, such as a
+// Cell, in order to show whether they are selected.
+type RowFactory func (index int, recycled tomo.Element) tomo.Element
+
+// VirtualList is a list that can hold a very large amount of rows. Instead of
+// being given an element for every row, it creates elements only for the rows
+// that are currently visible, and reuses them as it is scrolled. It is meant to
+// be placed inside of a Scroll.
+type VirtualList struct {
+	entity  tomo.Entity
+	factory RowFactory
+	count   int
+
+	rows map[int] tomo.Element
+	pool []tomo.Element
+
+	// if rowHeight is zero, the height of each row is measured once it is
+	// displayed, and the heights of the rest are estimated
+	rowHeight   int
+	heights     []int
+	measuredSum int
+	measured    int
+
+	selector selector
+	enabled  bool
+	selected []bool
+	cursor   int
+	anchor   int
+
+	scroll              int
+	minimumWidth        int
+	forcedMinimumWidth  int
+	forcedMinimumHeight int
+
+	lastClick   time.Time
+	lastClicked int
+
+	onActivate           func (index int)
+	onScrollBoundsChange func ()
+}
+
+// NewVirtualList creates a new virtual list with the specified amount of rows.
+// The factory is called to create or update the element for each row as it
+// becomes visible.
+func NewVirtualList (count int, factory RowFactory) (element *VirtualList) {
+	element = &VirtualList {
+		factory:     factory,
+		rows:        make(map[int] tomo.Element),
+		enabled:     true,
+		cursor:      -1,
+		anchor:      -1,
+		lastClicked: -1,
+	}
+	element.entity = tomo.GetBackend().NewEntity(element)
+	element.selector.items = element
+	element.SetCount(count)
+	return
+}
+
+// Entity returns this element's entity.
+func (element *VirtualList) Entity () tomo.Entity {
+	return element.entity
+}
+
+// Draw causes the element to draw to the specified destination canvas.
+func (element *VirtualList) Draw (destination art.Canvas) {
+	rocks := make([]image.Rectangle, element.entity.CountChildren())
+	for index := 0; index < element.entity.CountChildren(); index ++ {
+		rocks[index] = element.entity.Child(index).Entity().Bounds()
+	}
+
+	pattern := element.entity.Theme().Pattern(tomo.PatternSunken, element.state(), virtualListCase)
+	artutil.DrawShatter(destination, pattern, element.entity.Bounds(), rocks...)
+}
+
+// Layout causes this element to perform a layout operation.
+func (element *VirtualList) Layout () {
+	element.clampScroll()
+	bounds := element.inner()
+
+	// displayed rows are moved into a new map as they are visited, so
+	// whatever is left in the old one afterwards can be recycled
+	old := element.rows
+	element.rows = make(map[int] tomo.Element)
+	for index, row := range old {
+		if index < element.count { continue }
+		element.recycle(row)
+		delete(old, index)
+	}
+
+	index, y := element.rowAtOffset(element.scroll)
+	for ; index < element.count && y < element.scroll + bounds.Dy(); index ++ {
+		row, ok := old[index]
+		if ok {
+			delete(old, index)
+		} else {
+			row = element.bind(index)
+		}
+		element.rows[index] = row
+		childIndex := element.entity.IndexOf(row)
+		element.measure(index, childIndex)
+
+		height := element.heightOf(index)
+		top    := bounds.Min.Y + y - element.scroll
+		element.entity.PlaceChild (childIndex, image.Rect (
+			bounds.Min.X, top,
+			bounds.Max.X, top + height))
+		y += height
+	}
+	for _, row := range old {
+		element.recycle(row)
+	}
+
+	element.updateMinimumSize()
+	element.entity.NotifyScrollBoundsChange()
+	if element.onScrollBoundsChange != nil {
+		element.onScrollBoundsChange()
+	}
+}
+
+// DrawBackground draws this element's background pattern to the specified
+// destination canvas.
+func (element *VirtualList) DrawBackground (destination art.Canvas) {
+	element.entity.DrawBackground(destination)
+}
+
+// Count returns the amount of rows in the list.
+func (element *VirtualList) Count () int {
+	return element.count
+}
+
+// SetCount sets the amount of rows in the list. Rows that are already displayed
+// are not updated, so if their contents have changed, Refresh must be called as
+// well. Rows past the end of the list are deselected.
+func (element *VirtualList) SetCount (count int) {
+	if count < 0 { count = 0 }
+	selectionChanged := false
+	if count < len(element.selected) {
+		for _, selected := range element.selected[count:] {
+			if selected { selectionChanged = true }
+		}
+		element.selected = element.selected[:count]
+	}
+	for len(element.selected) < count {
+		element.selected = append(element.selected, false)
+	}
+	if element.rowHeight == 0 {
+		if count < len(element.heights) {
+			for _, height := range element.heights[count:] {
+				if height == 0 { continue }
+				element.measuredSum -= height
+				element.measured --
+			}
+			element.heights = element.heights[:count]
+		}
+		for len(element.heights) < count {
+			element.heights = append(element.heights, 0)
+		}
+	}
+	element.count = count
+	if element.cursor      >= count { element.cursor      = -1 }
+	if element.anchor      >= count { element.anchor      = -1 }
+	if element.lastClicked >= count { element.lastClicked = -1 }
+
+	element.changed()
+	if selectionChanged && element.selector.onSelectionChange != nil {
+		element.selector.onSelectionChange()
+	}
+}
+
+// Refresh calls the factory again for every row that is displayed, so that
+// they show up to date information.
+func (element *VirtualList) Refresh () {
+	for index, row := range element.rows {
+		childIndex := element.entity.IndexOf(row)
+		updated := element.factory(index, row)
+		if updated != row {
+			element.entity.Disown(childIndex)
+			element.entity.Adopt(updated)
+			childIndex = element.entity.IndexOf(updated)
+			element.rows[index] = updated
+		}
+		element.entity.SelectChild(childIndex, element.selected[index])
+		element.forget(index)
+	}
+	element.changed()
+}
+
+// SetRowHeight sets the height of every row in the list. If it is zero, the
+// height of each row is measured when it is first displayed, and the height of
+// rows that have not been displayed yet is estimated from the ones that have.
+// Rows all having the same height is much faster to compute for very long
+// lists. By default, row heights are measured.
+func (element *VirtualList) SetRowHeight (height int) {
+	if height < 0 { height = 0 }
+	if element.rowHeight == height { return }
+	element.rowHeight   = height
+	element.heights     = nil
+	element.measuredSum = 0
+	element.measured    = 0
+	if height == 0 {
+		element.heights = make([]int, element.count)
+	}
+	element.changed()
+}
+
+// SelectionMode returns how many rows can be selected at once.
+func (element *VirtualList) SelectionMode () SelectionMode {
+	return element.selector.mode
+}
+
+// SetSelectionMode sets how many rows can be selected at once. By default, only
+// one row can be selected.
+func (element *VirtualList) SetSelectionMode (mode SelectionMode) {
+	element.selector.setMode(mode)
+}
+
+// Selected returns the indices of the selected rows, in ascending order.
+func (element *VirtualList) Selected () (indices []int) {
+	for index, selected := range element.selected {
+		if selected { indices = append(indices, index) }
+	}
+	return
+}
+
+// Select selects the rows at the specified indices, deselecting all others. If
+// only one row can be selected at a time, only the first is used. Calling
+// Select with no indices clears the selection.
+func (element *VirtualList) Select (indices ...int) {
+	if element.selector.mode == SelectionSingle && len(indices) > 1 {
+		indices = indices[:1]
+	}
+	wanted := make(map[int] bool)
+	for _, index := range indices {
+		if index < 0 || index >= element.count { continue }
+		wanted[index]  = true
+		element.cursor = index
+		element.anchor = index
+	}
+	element.selector.setSelection (func (index int) bool {
+		return wanted[index]
+	})
+	element.scrollToCursor()
+}
+
+// OnSelectionChange sets a function to be called when rows are selected or
+// deselected.
+func (element *VirtualList) OnSelectionChange (callback func ()) {
+	element.selector.onSelectionChange = callback
+}
+
+// OnActivate sets a function to be called when a row is double-clicked, or when
+// Enter is pressed while it has the cursor.
+func (element *VirtualList) OnActivate (callback func (index int)) {
+	element.onActivate = callback
+}
+
+// Focus gives this element input focus.
+func (element *VirtualList) Focus () {
+	if !element.entity.Focused() { element.entity.Focus() }
+}
+
+// Enabled returns whether this list is enabled or not.
+func (element *VirtualList) Enabled () bool {
+	return element.enabled
+}
+
+// SetEnabled sets whether this list can be interacted with or not.
+func (element *VirtualList) SetEnabled (enabled bool) {
+	if element.enabled == enabled { return }
+	element.enabled = enabled
+	element.entity.Invalidate()
+}
+
+func (element *VirtualList) HandleFocusChange () {
+	element.entity.Invalidate()
+}
+
+func (element *VirtualList) HandleMouseDown (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) {
+	if !element.enabled { return }
+	element.Focus()
+	if button != input.ButtonLeft { return }
+	if !modifiers.Control && !modifiers.Shift {
+		element.selector.selectNone()
+	}
+}
+
+func (element *VirtualList) HandleMouseUp (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+) { }
+
+func (element *VirtualList) HandleChildMouseDown (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+	child tomo.Element,
+) {
+	if !element.enabled { return }
+	element.Focus()
+	if button != input.ButtonLeft { return }
+	index := element.rowIndex(child)
+	if index < 0 { return }
+	element.pick(index, modifiers, false)
+
+	delay := element.entity.Config().DoubleClickDelay()
+	if index == element.lastClicked && time.Since(element.lastClick) < delay {
+		element.lastClicked = -1
+		element.activate(index)
+	} else {
+		element.lastClicked = index
+		element.lastClick   = time.Now()
+	}
+}
+
+func (element *VirtualList) HandleChildMouseUp (
+	position image.Point,
+	button input.Button,
+	modifiers input.Modifiers,
+	child tomo.Element,
+) { }
+
+func (element *VirtualList) HandleKeyDown (key input.Key, modifiers input.Modifiers) {
+	if !element.enabled { return }
+	if key == 'a' && modifiers.Control {
+		element.selector.selectAll()
+		return
+	}
+
+	if element.count == 0 { return }
+	index := element.cursor
+	last  := element.count - 1
+
+	switch key {
+	case input.KeyUp, input.KeyLeft:
+		if index < 1 { index = 1 }
+		element.pick(index - 1, modifiers, true)
+	case input.KeyDown, input.KeyRight:
+		element.pick(index + 1, modifiers, true)
+	case input.KeyPageUp:
+		index -= element.pageRows()
+		if index < 0 { index = 0 }
+		element.pick(index, modifiers, true)
+	case input.KeyPageDown:
+		index += element.pageRows()
+		if index > last { index = last }
+		element.pick(index, modifiers, true)
+	case input.KeyHome:
+		element.pick(0, modifiers, true)
+	case input.KeyEnd:
+		element.pick(last, modifiers, true)
+	case ' ':
+		if index >= 0 { element.pick(index, modifiers, false) }
+	case input.KeyEnter:
+		if index >= 0 { element.activate(index) }
+	}
+}
+
+func (element *VirtualList) HandleKeyUp (key input.Key, modifiers input.Modifiers) { }
+
+func (element *VirtualList) HandleChildMinimumSizeChange (child tomo.Element) {
+	if index := element.rowIndex(child); index >= 0 {
+		element.forget(index)
+	}
+	element.changed()
+}
+
+func (element *VirtualList) HandleThemeChange () {
+	if element.rowHeight == 0 {
+		element.heights     = make([]int, element.count)
+		element.measuredSum = 0
+		element.measured    = 0
+	}
+	element.minimumWidth = 0
+	element.changed()
+}
+
+// Collapse forces a minimum width and height upon the list. If a zero value is
+// given for a dimension, its minimum will be determined by the list's content.
+func (element *VirtualList) Collapse (width, height int) {
+	if
+		element.forcedMinimumWidth == width &&
+		element.forcedMinimumHeight == height {
+
+		return
+	}
+
+	element.forcedMinimumWidth  = width
+	element.forcedMinimumHeight = height
+	element.changed()
+}
+
+// ScrollContentBounds returns the full content size of the element.
+func (element *VirtualList) ScrollContentBounds () image.Rectangle {
+	return image.Rect (
+		0, 0,
+		element.inner().Dx(),
+		element.offsetOf(element.count))
+}
+
+// ScrollViewportBounds returns the size and position of the element's
+// viewport relative to ScrollBounds.
+func (element *VirtualList) ScrollViewportBounds () image.Rectangle {
+	bounds := element.inner()
+	return bounds.Sub(bounds.Min).Add(image.Pt(0, element.scroll))
+}
+
+// ScrollTo scrolls the viewport to the specified point relative to
+// ScrollBounds.
+func (element *VirtualList) ScrollTo (position image.Point) {
+	element.scroll = position.Y
+	element.clampScroll()
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+// ScrollAxes returns the supported axes for scrolling.
+func (element *VirtualList) ScrollAxes () (horizontal, vertical bool) {
+	return false, true
+}
+
+// OnScrollBoundsChange sets a function to be called when the element's viewport
+// bounds, content bounds, or scroll axes change.
+func (element *VirtualList) OnScrollBoundsChange (callback func ()) {
+	element.onScrollBoundsChange = callback
+}
+
+// bind gets an element for the row at the specified index from the factory,
+// reusing one from the pool if possible, and adopts it.
+func (element *VirtualList) bind (index int) tomo.Element {
+	var recycled tomo.Element
+	if len(element.pool) > 0 {
+		recycled = element.pool[len(element.pool) - 1]
+		element.pool = element.pool[:len(element.pool) - 1]
+	}
+	row := element.factory(index, recycled)
+	element.entity.Adopt(row)
+	element.entity.SelectChild(element.entity.IndexOf(row), element.selected[index])
+	return row
+}
+
+// recycle removes a row that is no longer displayed and puts it in the pool.
+func (element *VirtualList) recycle (row tomo.Element) {
+	element.entity.Disown(element.entity.IndexOf(row))
+	element.pool = append(element.pool, row)
+}
+
+// measure records the height of a displayed row, if it hasn't been already.
+func (element *VirtualList) measure (index, childIndex int) {
+	if element.rowHeight > 0 || element.heights[index] > 0 { return }
+	_, height := element.entity.ChildMinimumSize(childIndex)
+	if height < 1 { height = 1 }
+	element.heights[index] = height
+	element.measuredSum += height
+	element.measured ++
+}
+
+// forget discards the measured height of a row, so that it is measured again
+// the next time the list is laid out.
+func (element *VirtualList) forget (index int) {
+	if element.rowHeight > 0 || element.heights[index] == 0 { return }
+	element.measuredSum -= element.heights[index]
+	element.measured --
+	element.heights[index] = 0
+}
+
+func (element *VirtualList) heightOf (index int) int {
+	if element.rowHeight > 0 { return element.rowHeight }
+	if element.heights[index] > 0 { return element.heights[index] }
+	return element.estimate()
+}
+
+// estimate returns the height assumed for rows that haven't been measured yet.
+func (element *VirtualList) estimate () int {
+	if element.measured == 0 {
+		return element.entity.Theme().FontFace (
+			tomo.FontStyleRegular,
+			tomo.FontSizeNormal,
+			virtualListCase).Metrics().Height.Ceil()
+	}
+	return element.measuredSum / element.measured
+}
+
+// offsetOf returns the distance from the top of the content to the top of the
+// row at the specified index.
+func (element *VirtualList) offsetOf (index int) (offset int) {
+	if element.rowHeight > 0 { return index * element.rowHeight }
+	estimate := element.estimate()
+	for current := 0; current < index; current ++ {
+		if height := element.heights[current]; height > 0 {
+			offset += height
+		} else {
+			offset += estimate
+		}
+	}
+	return
+}
+
+// rowAtOffset returns the index of the row that contains the specified distance
+// from the top of the content, and the distance to the top of that row.
+func (element *VirtualList) rowAtOffset (offset int) (index, top int) {
+	if element.rowHeight > 0 {
+		index = offset / element.rowHeight
+		return index, index * element.rowHeight
+	}
+	estimate := element.estimate()
+	for ; index < element.count; index ++ {
+		height := element.heights[index]
+		if height == 0 { height = estimate }
+		if top + height > offset { break }
+		top += height
+	}
+	return
+}
+
+// pick moves the cursor to the row at the specified index, and changes the
+// selection depending on which modifier keys are held down.
+func (element *VirtualList) pick (index int, modifiers input.Modifiers, keyboard bool) {
+	element.selector.pick(index, modifiers, keyboard)
+	element.scrollToCursor()
+}
+
+func (element *VirtualList) itemCount () int {
+	return element.count
+}
+
+func (element *VirtualList) itemSelected (index int) bool {
+	return element.selected[index]
+}
+
+func (element *VirtualList) selectItem (index int, selected bool) (changed bool) {
+	if element.selected[index] == selected { return false }
+	element.selected[index] = selected
+	if row, ok := element.rows[index]; ok {
+		element.entity.SelectChild(element.entity.IndexOf(row), selected)
+	}
+	element.entity.Invalidate()
+	return true
+}
+
+func (element *VirtualList) cursorIndex () int {
+	return element.cursor
+}
+
+func (element *VirtualList) setCursorIndex (index int) {
+	element.cursor = index
+}
+
+func (element *VirtualList) anchorIndex () int {
+	return element.anchor
+}
+
+func (element *VirtualList) setAnchorIndex (index int) {
+	element.anchor = index
+}
+
+func (element *VirtualList) activate (index int) {
+	if element.onActivate != nil {
+		element.onActivate(index)
+	}
+}
+
+func (element *VirtualList) scrollToCursor () {
+	if element.cursor < 0 { return }
+	top    := element.offsetOf(element.cursor)
+	bottom := top + element.heightOf(element.cursor)
+	height := element.inner().Dy()
+	if top < element.scroll {
+		element.ScrollTo(image.Pt(0, top))
+	} else if bottom > element.scroll + height {
+		element.ScrollTo(image.Pt(0, bottom - height))
+	}
+}
+
+func (element *VirtualList) clampScroll () {
+	maxScroll := element.offsetOf(element.count) - element.inner().Dy()
+	if element.scroll > maxScroll { element.scroll = maxScroll }
+	if element.scroll < 0         { element.scroll = 0         }
+}
+
+func (element *VirtualList) rowIndex (child tomo.Element) int {
+	for index, row := range element.rows {
+		if row == child { return index }
+	}
+	return -1
+}
+
+func (element *VirtualList) pageRows () int {
+	if len(element.rows) < 2 { return 1 }
+	return len(element.rows) - 1
+}
+
+func (element *VirtualList) inner () image.Rectangle {
+	padding := element.entity.Theme().Padding(tomo.PatternSunken, virtualListCase)
+	return padding.Apply(element.entity.Bounds())
+}
+
+func (element *VirtualList) state () tomo.State {
+	return tomo.State {
+		Focused:  element.entity.Focused(),
+		Disabled: !element.enabled,
+	}
+}
+
+func (element *VirtualList) changed () {
+	element.updateMinimumSize()
+	element.entity.Invalidate()
+	element.entity.InvalidateLayout()
+}
+
+func (element *VirtualList) updateMinimumSize () {
+	padding := element.entity.Theme().Padding(tomo.PatternSunken, virtualListCase)
+
+	// the minimum width only ever grows, otherwise it could change back
+	// and forth as different rows are scrolled into view
+	for _, row := range element.rows {
+		width, _ := element.entity.ChildMinimumSize(element.entity.IndexOf(row))
+		if width > element.minimumWidth { element.minimumWidth = width }
+	}
+
+	width  := element.minimumWidth + padding.Horizontal()
+	height := padding.Vertical()
+	if element.count > 0 {
+		height += element.heightOf(0)
+	}
+	if element.forcedMinimumWidth > 0 {
+		width = element.forcedMinimumWidth
+	}
+	if element.forcedMinimumHeight > 0 {
+		height = element.forcedMinimumHeight
+	}
+	element.entity.SetMinimumSize(width, height)
+}
@@ -57,7 +57,9 @@ type Entity interface {
 	CountChildren () int
 
 	// PlaceChild sets the size and position of the child at the specified
-	// index to a bounding rectangle.
+	// index to a bounding rectangle. Children may overlap, in which case
+	// children later in the list are drawn on top of, and receive mouse
+	// events before, children earlier in the list.
 	PlaceChild (index int, bounds image.Rectangle)
 
 	// SelectChild marks a child as selected or unselected, if it is
@@ -31,11 +31,14 @@ func (Application) Init () error {
 	container := elements.NewVBox(elements.SpaceBoth)
 	textInput := elements.NewTextBox("", "")
 	controlRow := elements.NewHBox(elements.SpaceMargin)
-	copyButton := elements.NewButton("Copy")
+	copyButton := elements.NewButton("")
+	copyButton.SetTextWithMnemonic("_Copy")
 	copyButton.SetIcon(tomo.IconCopy)
-	pasteButton := elements.NewButton("Paste")
+	pasteButton := elements.NewButton("")
+	pasteButton.SetTextWithMnemonic("_Paste")
 	pasteButton.SetIcon(tomo.IconPaste)
-	pasteImageButton := elements.NewButton("Image")
+	pasteImageButton := elements.NewButton("")
+	pasteImageButton.SetTextWithMnemonic("_Image")
 	pasteImageButton.SetIcon(tomo.IconPictures)
 
 	imageClipboardCallback := func (clipboard data.Data, err error) {
@@ -57,6 +57,11 @@ func (Application) Init () error {
 	for i := 0; i < 30; i ++ {
 		document.AdoptInline(elements.NewSwitch("", false))
 	}
+	details := elements.NewExpander ("", elements.NewLabelWrapped (
+		"Sections can be hidden away inside of an expander, which " +
+		"can be opened by clicking on its header."))
+	details.SetTextWithMnemonic("_More details")
+	document.Adopt(details)
 
 	window.Adopt(elements.NewScroll(elements.ScrollVertical, document))
 	window.OnClose(nasin.Stop)
@@ -1,6 +1,7 @@
 package main
 
 import "os"
+import "fmt"
 import "path/filepath"
 import "tomo"
 import "tomo/nasin"
@@ -35,6 +36,8 @@ func (Application) Init () error {
 	upwardButton.SetIcon(tomo.IconUpward)
 	upwardButton.ShowText(false)
 	locationInput := elements.NewTextBox("Location", "")
+	detailsButton := elements.NewToggleButton("Details", false)
+	hiddenButton  := elements.NewToggleButton("Hidden", false)
 	
 	statusBar := elements.NewHBox(elements.SpaceMargin)
 	directory, _ := elements.NewFile(homeDir, nil)
@@ -73,13 +76,48 @@ func (Application) Init () error {
 		choose(filepath.Dir(filePath))
 	})
 
+	selectionCount := elements.NewLabel("")
+	directoryView.OnSelectionChange (func () {
+		count := len(directoryView.SelectedFiles())
+		if count == 0 {
+			selectionCount.SetText("")
+		} else {
+			selectionCount.SetText(fmt.Sprint("(", count, " selected)"))
+		}
+	})
+
+	detailsButton.OnToggle (func () {
+		if detailsButton.Value() {
+			directoryView.SetView(elements.DirectoryViewDetails)
+		} else {
+			directoryView.SetView(elements.DirectoryViewIcons)
+		}
+	})
+	hiddenButton.OnToggle (func () {
+		directoryView.SetShowHidden(hiddenButton.Value())
+	})
+
 	controlBar.Adopt(backButton, forwardButton, refreshButton, upwardButton)
 	controlBar.AdoptExpand(locationInput)
-	statusBar.Adopt(directory, baseName)
+	controlBar.Adopt(detailsButton, hiddenButton)
+	statusBar.Adopt(directory, baseName, selectionCount)
 	
 	container.Adopt(controlBar)
-	container.AdoptExpand (
-		elements.NewScroll(elements.ScrollVertical, directoryView))
+	// a sidebar of places that can be collapsed by dragging the divider
+	homeButton := elements.NewButton("Home")
+	homeButton.SetIcon(tomo.IconHome)
+	homeButton.OnClick(func () { choose(homeDir) })
+	rootButton := elements.NewButton("Root")
+	rootButton.SetIcon(tomo.IconStorage)
+	rootButton.OnClick(func () { choose("/") })
+	sidebar := elements.NewVBox(elements.SpaceNone, homeButton, rootButton)
+
+	paned := elements.NewHPaned (
+		sidebar,
+		elements.NewScroll(elements.ScrollBoth, directoryView))
+	paned.SetCollapsible(true, false)
+	paned.SetPosition(0.25)
+	container.AdoptExpand(paned)
 	container.Adopt(statusBar)
 
 	window.OnClose(nasin.Stop)
@@ -0,0 +1,33 @@
+package main
+
+import "tomo"
+import "tomo/nasin"
+import "tomo/elements"
+
+func main () {
+	nasin.Run(Application { })
+}
+
+type Application struct { }
+
+func (Application) Init () error {
+	window, err := nasin.NewWindow(tomo.Bounds(0, 0, 0, 0))
+	if err != nil { return err }
+	window.SetTitle("Form")
+
+	form := elements.NewForm(elements.SpaceBoth)
+	form.AddHeading("Account")
+	form.AddField("_Username:", elements.NewTextBox("", ""))
+	password := elements.NewTextBox("", "")
+	password.SetMask('•')
+	form.AddField("_Password:", password)
+
+	form.AddHeading("Preferences")
+	form.AddField("_Font size:", elements.NewSpinBox(6, 72, 12))
+	form.AddRow(elements.NewCheckbox("Remember me", false))
+
+	window.Adopt(form)
+	window.OnClose(nasin.Stop)
+	window.Show()
+	return nil
+}
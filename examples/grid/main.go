@@ -0,0 +1,51 @@
+package main
+
+import "tomo"
+import "tomo/nasin"
+import "tomo/elements"
+
+func main () {
+	nasin.Run(Application { })
+}
+
+type Application struct { }
+
+func (Application) Init () error {
+	window, err := nasin.NewWindow(tomo.Bounds(0, 0, 0, 0))
+	if err != nil { return err }
+	window.SetTitle("Grid")
+
+	grid := elements.NewGrid (
+		elements.SpaceBoth,
+		elements.Track { },
+		elements.Track { Weight: 1 },
+		elements.Track { Weight: 2 })
+
+	// a heading that spans every column
+	heading := elements.NewLabel("Shipping address")
+	grid.Attach(heading, 0, 0, 3, 1)
+	grid.SetAlign(heading, elements.AlignMiddle, elements.AlignFill)
+
+	// labels go in the first column, and fields take up the rest
+	street := elements.NewLabel("Street:")
+	grid.Attach(street, 0, 1, 1, 1)
+	grid.SetAlign(street, elements.AlignEnd, elements.AlignMiddle)
+	grid.Attach(elements.NewTextBox("", ""), 1, 1, 2, 1)
+
+	city := elements.NewLabel("City:")
+	grid.Attach(city, 0, 2, 1, 1)
+	grid.SetAlign(city, elements.AlignEnd, elements.AlignMiddle)
+	grid.Attach(elements.NewTextBox("", ""), 1, 2, 1, 1)
+	grid.Attach(elements.NewTextBox("Postal code", ""), 2, 2, 1, 1)
+
+	// the remaining cells are filled in order
+	grid.Adopt (
+		elements.NewSpacer(),
+		elements.NewButton("Cancel"),
+		elements.NewButton("Save"))
+
+	window.Adopt(grid)
+	window.OnClose(nasin.Stop)
+	window.Show()
+	return nil
+}
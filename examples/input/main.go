@@ -1,9 +1,12 @@
 package main
 
+import "fmt"
+import "strings"
 import "tomo"
 import "tomo/nasin"
 import "tomo/popups"
 import "tomo/elements"
+import "tomo/validate"
 
 func main () {
 	nasin.Run(Application { })
@@ -21,7 +24,8 @@ func (Application) Init () error {
 	// create inputs
 	firstName    := elements.NewTextBox("First name", "")
 	lastName     := elements.NewTextBox("Last name", "")
-	fingerLength := elements.NewTextBox("Length of fingers", "")
+	email        := elements.NewTextBox("Email", "")
+	fingerLength := elements.NewSpinBox(0.0, 100.0, 3.0)
 	purpose      := elements.NewComboBox (
 		"",
 		"Gaslight",
@@ -29,36 +33,62 @@ func (Application) Init () error {
 		"Girlboss")
 	button       := elements.NewButton("Ok")
 
+	email.SetValidator(validate.Chain (
+		validate.MaxLength(64),
+		validate.Transform(strings.TrimSpace),
+		validate.Email()))
+
+	// let the user type in a purpose that isn't listed
+	purpose.SetEditable(true)
+	fingerLength.SetStep(0.5)
+	fingerLength.SetPrecision(1)
+
+	// create a group of radio buttons to choose the unit of measurement
+	feet   := elements.NewRadioButton("", true)
+	inches := elements.NewRadioButton("", false)
+	meters := elements.NewRadioButton("", false)
+	feet.SetTextWithMnemonic("_Feet")
+	inches.SetTextWithMnemonic("_Inches")
+	meters.SetTextWithMnemonic("_Meters")
+	units  := elements.NewRadioGroup[string]()
+	units.Add(feet,   "feet")
+	units.Add(inches, "inches")
+	units.Add(meters, "meters")
+
 	button.SetEnabled(false)
 	button.OnClick (func () {
 		// create a dialog displaying the results
+		unit, _ := units.Value()
 		popups.NewDialog (
 			popups.DialogKindInfo,
 			window,
 			"Profile",
 			firstName.Value() + " " + lastName.Value() +
-			"'s fingers\nmeasure in at " + fingerLength.Value() +
-			" feet.")
+			"'s fingers\nmeasure in at " + fmt.Sprint(fingerLength.Value()) +
+			" " + unit + ".")
 	})
 
-	// enable the Ok button if all three inputs have text in them
+	// enable the Ok button if all inputs have been filled out
 	check := func () {
 		button.SetEnabled (
-			firstName.Filled()    &&
-			lastName.Filled()     &&
-			fingerLength.Filled() &&
+			firstName.Filled() &&
+			lastName.Filled()  &&
+			email.Filled()     &&
+			email.Valid()      &&
 			purpose.Filled())
 	}
 	firstName.OnChange(check)
 	lastName.OnChange(check)
-	fingerLength.OnChange(check)
+	email.OnChange(check)
 	purpose.OnChange(check)
 
 	// add elements to container
 	container.AdoptExpand(elements.NewLabel("Choose your words carefully."))
 	container.Adopt (
-		firstName, lastName,
+		firstName, lastName, email,
+		elements.NewLabel("Length of fingers:"),
 		fingerLength,
+		elements.NewHBox(elements.SpaceMargin, feet, inches, meters),
 		elements.NewLabel("Purpose:"),
 		purpose,
 		elements.NewLine(), button)
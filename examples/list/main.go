@@ -39,13 +39,35 @@ func (Application) Init () error {
 	})
 	mouse  := testing.NewMouse()
 	input  := elements.NewTextBox("Write some text", "")
+	parts := []*elements.Checkbox {
+		elements.NewCheckbox("Skin", true),
+		elements.NewCheckbox("Blood", false),
+		elements.NewCheckbox("Bone", false),
+	}
+	// the "select all" checkbox is indeterminate when only some of the
+	// parts are checked
+	all := elements.NewCheckbox("I have everything", false)
+	all.OnToggle (func () {
+		for _, part := range parts { part.SetState(all.State()) }
+	})
+	updateAll := func () {
+		count := 0
+		for _, part := range parts {
+			if part.Value() { count ++ }
+		}
+		switch count {
+		case 0:          all.SetState(elements.CheckStateUnchecked)
+		case len(parts): all.SetState(elements.CheckStateChecked)
+		default:         all.SetState(elements.CheckStateIndeterminate)
+		}
+	}
+	for _, part := range parts { part.OnToggle(updateAll) }
+	updateAll()
 	form := elements.NewVBox (
 		elements.SpaceMargin,
-		elements.NewLabel("I have:"),
+		all,
 		elements.NewLine(),
-		elements.NewCheckbox("Skin", true),
-		elements.NewCheckbox("Blood", false),
-		elements.NewCheckbox("Bone", false))
+		parts[0], parts[1], parts[2])
 	art := testing.NewArtist()
 
 	makePage := func (name string, callback func ()) ability.Selectable {
@@ -0,0 +1,85 @@
+package main
+
+import "tomo"
+import "tomo/menu"
+import "tomo/input"
+import "tomo/nasin"
+import "tomo/elements"
+
+func main () {
+	nasin.Run(Application { })
+}
+
+type Application struct { }
+
+func (Application) Init () error {
+	window, err := nasin.NewWindow(tomo.Bounds(0, 0, 256, 0))
+	if err != nil { return err }
+	window.SetTitle("Menus")
+
+	container := elements.NewVBox(elements.SpaceNone)
+	window.Adopt(container)
+
+	status := elements.NewLabel("Pick something from a menu.")
+	say := func (text string) func () {
+		return func () { status.SetText(text) }
+	}
+	ctrl := input.Modifiers { Control: true }
+
+	save := menu.Action("_Save", say("Saved."))
+	save.Icon = tomo.IconSave
+	save.Accelerator = tomo.A('s', ctrl)
+	quit := menu.Action("_Quit", nasin.Stop)
+	quit.Accelerator = tomo.A('q', ctrl)
+	
+	bar := elements.NewMenuBar (
+		menu.Sub ("_File",
+			menu.Action("_New", say("Created a new file.")),
+			save,
+			menu.Sub ("_Export",
+				menu.Action("As _PNG", say("Exported as PNG.")),
+				menu.Action("As _JPEG", say("Exported as JPEG."))),
+			menu.Separator(),
+			quit),
+		menu.Sub ("_View",
+			menu.Check("Show _Toolbar", true, say("Toggled the toolbar.")),
+			menu.Separator(),
+			menu.Radio("_Icons",   true,  say("Showing icons.")),
+			menu.Radio("_List",    false, say("Showing a list.")),
+			menu.Radio("_Details", false, say("Showing details."))))
+	err = bar.RegisterAccelerators(window)
+	if err != nil { return err }
+	
+	// toolbars are described with the same items as menus
+	tool := func (text string, icon tomo.Icon) *menu.Item {
+		item := menu.Action(text, say(text + " was clicked."))
+		item.Icon = icon
+		return item
+	}
+	preview := menu.Check("Preview", false, say("Toggled the preview."))
+	preview.Icon = tomo.IconSearch
+	toolbar := elements.NewToolbar (
+		tool("New",  tomo.IconNew),
+		tool("Open", tomo.IconOpen),
+		tool("Save", tomo.IconSave),
+		menu.Separator(),
+		tool("Cut",   tomo.IconCut),
+		tool("Copy",  tomo.IconCopy),
+		tool("Paste", tomo.IconPaste),
+		menu.Separator(),
+		preview)
+
+	statusBar := elements.NewStatusBar()
+	statusBar.AddSegment("Ready", true)
+	statusBar.AddSegment("Line 1, column 1", false)
+
+	container.Adopt(bar, toolbar)
+	inner := elements.NewVBox(elements.SpaceBoth)
+	inner.AdoptExpand(status)
+	container.AdoptExpand(inner)
+	container.Adopt(statusBar)
+		
+	window.OnClose(nasin.Stop)
+	window.Show()
+	return nil
+}
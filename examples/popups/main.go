@@ -39,9 +39,9 @@ func (Application) Init () error {
 			window,
 			"The Big Question",
 			"Are you real?",
-			popups.Button { "Yes",      func () { } },
-			popups.Button { "No",       func () { } },
-			popups.Button { "Not sure", func () { } })
+			popups.Button { "_Yes",      func () { }, true },
+			popups.Button { "_No",       func () { }, true },
+			popups.Button { "Not _sure", func () { }, true })
 	})
 	container.Adopt(questionButton)
 	
@@ -21,6 +21,7 @@ func (Application) Init () error {
 
 	container.AdoptExpand(elements.NewLabel("Rapidly approaching your location..."))
 	bar := elements.NewProgressBar(0)
+	bar.SetFormat(elements.FormatPercent)
 	container.Adopt(bar)
 	button := elements.NewButton("Stop")
 	button.SetEnabled(false)
@@ -0,0 +1,57 @@
+package main
+
+import "tomo"
+import "tomo/nasin"
+import "tomo/elements"
+
+func main () {
+	nasin.Run(Application { })
+}
+
+type Application struct { }
+
+func (Application) Init () error {
+	window, err := nasin.NewWindow(tomo.Bounds(0, 0, 0, 0))
+	if err != nil { return err }
+	window.SetTitle("Stack")
+
+	// a loading message layered over some content
+	content := elements.NewVBox (
+		elements.SpaceBoth,
+		elements.NewLabel("Some content"),
+		elements.NewTextBox("Type here", ""))
+	overlay := elements.NewLabel("Loading...")
+	layers := elements.NewStack(elements.SpaceNone, content, overlay)
+	layers.SetAlign(overlay, elements.AlignEnd, elements.AlignEnd)
+	loading := elements.NewCheckbox("Show loading message", true)
+	loading.OnToggle (func () {
+		if loading.Value() {
+			layers.Adopt(overlay)
+			layers.SetAlign(overlay, elements.AlignEnd, elements.AlignEnd)
+		} else {
+			layers.Disown(overlay)
+		}
+	})
+
+	// a stack showing one page at a time
+	first  := elements.NewLabel("This is the first page.")
+	second := elements.NewLabel("This is the second page.")
+	pages := elements.NewStack(elements.SpacePadding, first, second)
+	pages.SetVisibleChild(first)
+	firstButton := elements.NewButton("First")
+	firstButton.OnClick(func () { pages.SetVisibleChild(first) })
+	secondButton := elements.NewButton("Second")
+	secondButton.OnClick(func () { pages.SetVisibleChild(second) })
+
+	container := elements.NewVBox(elements.SpaceBoth)
+	container.AdoptExpand(layers)
+	container.Adopt(loading)
+	container.Adopt(elements.NewHBox (
+		elements.SpaceMargin,
+		firstButton, secondButton))
+	container.AdoptExpand(pages)
+	window.Adopt(container)
+	window.OnClose(nasin.Stop)
+	window.Show()
+	return nil
+}
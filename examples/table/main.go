@@ -0,0 +1,68 @@
+package main
+
+import "fmt"
+import "tomo"
+import "tomo/nasin"
+import "tomo/elements"
+
+func main () {
+	nasin.Run(Application { })
+}
+
+type Application struct { }
+
+func (Application) Init () error {
+	window, err := nasin.NewWindow(tomo.Bounds(0, 0, 0, 0))
+	if err != nil { return err }
+	window.SetTitle("Table")
+
+	table := elements.NewTable(planets)
+	table.SetSelectionMode(elements.SelectionMultiple)
+	table.Collapse(0, 160)
+
+	status := elements.NewLabel("Click a header to sort by it.")
+	table.OnSelectionChange (func () {
+		status.SetText(fmt.Sprint(len(table.Selected()), " planets selected."))
+	})
+	table.OnActivate (func (row int) {
+		status.SetText(planets.Cell(row, 0) + " was chosen.")
+	})
+
+	container := elements.NewVBox(elements.SpaceBoth)
+	container.AdoptExpand(elements.NewScroll(elements.ScrollBoth, table))
+	container.Adopt(status)
+
+	window.Adopt(container)
+	window.OnClose(nasin.Stop)
+	window.Show()
+	return nil
+}
+
+type planetTable [][]string
+
+var planets = planetTable {
+	{ "Mercury", "0",   "0.39",  "88"    },
+	{ "Venus",   "0",   "0.72",  "225"   },
+	{ "Earth",   "1",   "1.00",  "365"   },
+	{ "Mars",    "2",   "1.52",  "687"   },
+	{ "Jupiter", "95",  "5.20",  "4333"  },
+	{ "Saturn",  "146", "9.54",  "10759" },
+	{ "Uranus",  "28",  "19.19", "30687" },
+	{ "Neptune", "16",  "30.07", "60190" },
+}
+
+func (planetTable) Columns () int {
+	return 4
+}
+
+func (planetTable) ColumnName (column int) string {
+	return []string { "Name", "Moons", "Distance (AU)", "Year (days)" }[column]
+}
+
+func (table planetTable) Rows () int {
+	return len(table)
+}
+
+func (table planetTable) Cell (row, column int) string {
+	return table[row][column]
+}
@@ -0,0 +1,47 @@
+package main
+
+import "fmt"
+import "tomo"
+import "tomo/nasin"
+import "tomo/elements"
+
+func main () {
+	nasin.Run(Application { })
+}
+
+type Application struct { }
+
+func (Application) Init () error {
+	window, err := nasin.NewWindow(tomo.Bounds(0, 0, 320, 240))
+	if err != nil { return err }
+	window.SetTitle("Tabs")
+
+	tabs := elements.NewTabs()
+	count := 0
+	newTab := func () {
+		count ++
+		name := fmt.Sprint("Document ", count)
+		tabs.AddTab(name, tomo.IconFile, elements.NewTextBox(name, ""))
+		tabs.SetClosable(tabs.CountTabs() - 1, true)
+		tabs.Select(tabs.CountTabs() - 1)
+	}
+	for index := 0; index < 3; index ++ { newTab() }
+	tabs.OnTabClose (func (page tomo.Element) bool {
+		// always keep at least one tab open
+		return tabs.CountTabs() > 1
+	})
+	err = tabs.RegisterAccelerators(window)
+	if err != nil { return err }
+
+	newButton := elements.NewButton("New Tab")
+	newButton.SetIcon(tomo.IconNew)
+	newButton.OnClick(newTab)
+
+	container := elements.NewVBox(elements.SpaceBoth)
+	container.Adopt(newButton)
+	container.AdoptExpand(tabs)
+	window.Adopt(container)
+	window.OnClose(nasin.Stop)
+	window.Show()
+	return nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import "os"
+import "path/filepath"
+import "tomo"
+import "tomo/nasin"
+import "tomo/elements"
+
+func main () {
+	nasin.Run(Application { })
+}
+
+type Application struct { }
+
+func (Application) Init () error {
+	window, err := nasin.NewWindow(tomo.Bounds(0, 0, 256, 0))
+	if err != nil { return err }
+	window.SetTitle("Tree")
+	homeDir, err := os.UserHomeDir()
+	if err != nil { return err }
+
+	tree := elements.NewTree(fileModel { root: homeDir })
+	tree.SetSelectionMode(elements.SelectionMultiple)
+	tree.Collapse(0, 256)
+
+	status := elements.NewLabel("Double-click a file to choose it.")
+	tree.OnSelectionChange (func () {
+		selected := tree.Selected()
+		switch len(selected) {
+		case 0:  status.SetText("Nothing is selected.")
+		case 1:  status.SetText(filepath.Base(selected[0].(string)))
+		default: status.SetText("Several files are selected.")
+		}
+	})
+	tree.OnActivate (func (node elements.TreeNode) {
+		status.SetText("Chose " + node.(string))
+	})
+
+	container := elements.NewVBox (
+		elements.SpaceBoth,
+		elements.NewLabel(homeDir))
+	container.AdoptExpand(elements.NewScroll(elements.ScrollVertical, tree))
+	container.Adopt(status)
+
+	window.Adopt(container)
+	window.OnClose(nasin.Stop)
+	window.Show()
+	return nil
+}
+
+// fileModel presents a directory on the file system as a tree. Nodes are file
+// paths, and the contents of a directory are only read when it is expanded.
+type fileModel struct {
+	root string
+}
+
+func (model fileModel) Children (node elements.TreeNode) (children []elements.TreeNode) {
+	location := model.root
+	if node != nil { location = node.(string) }
+	entries, err := os.ReadDir(location)
+	if err != nil { return nil }
+	for _, entry := range entries {
+		children = append(children, filepath.Join(location, entry.Name()))
+	}
+	return
+}
+
+func (model fileModel) Expandable (node elements.TreeNode) bool {
+	info, err := os.Stat(node.(string))
+	return err == nil && info.IsDir()
+}
+
+func (model fileModel) Text (node elements.TreeNode) string {
+	return filepath.Base(node.(string))
+}
+
+func (model fileModel) Icon (node elements.TreeNode) tomo.Icon {
+	if model.Expandable(node) {
+		return tomo.IconDirectory
+	} else {
+		return tomo.IconFile
+	}
+}
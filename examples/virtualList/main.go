@@ -0,0 +1,52 @@
+package main
+
+import "fmt"
+import "tomo"
+import "tomo/nasin"
+import "tomo/elements"
+
+func main () {
+	nasin.Run(Application { })
+}
+
+type Application struct { }
+
+func (Application) Init () error {
+	window, err := nasin.NewWindow(tomo.Bounds(0, 0, 256, 0))
+	if err != nil { return err }
+	window.SetTitle("Virtual list")
+
+	line := func (index int) string {
+		return fmt.Sprintf("Log line #%d", index + 1)
+	}
+
+	// rows are only created for the part of the list that is visible, and
+	// are reused as the list is scrolled
+	list := elements.NewVirtualList (100000, func (index int, recycled tomo.Element) tomo.Element {
+		if cell, ok := recycled.(*elements.Cell); ok {
+			cell.Child().(*elements.Label).SetText(line(index))
+			return cell
+		}
+		return elements.NewCell(elements.NewLabel(line(index)))
+	})
+	list.SetSelectionMode(elements.SelectionMultiple)
+	list.Collapse(0, 256)
+
+	status := elements.NewLabel("Nothing is selected.")
+	list.OnSelectionChange (func () {
+		status.SetText(fmt.Sprint(len(list.Selected()), " lines selected."))
+	})
+	more := elements.NewButton("Add a line")
+	more.OnClick (func () {
+		list.SetCount(list.Count() + 1)
+	})
+
+	container := elements.NewVBox(elements.SpaceBoth)
+	container.AdoptExpand(elements.NewScroll(elements.ScrollVertical, list))
+	container.Adopt(elements.NewHBox(elements.SpaceMargin, status, more))
+
+	window.Adopt(container)
+	window.OnClose(nasin.Stop)
+	window.Show()
+	return nil
+}
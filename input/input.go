@@ -59,6 +59,50 @@ const (
 	KeyF10 Key = 138
 	KeyF11 Key = 139
 	KeyF12 Key = 140
+	KeyF13 Key = 141
+	KeyF14 Key = 142
+	KeyF15 Key = 143
+	KeyF16 Key = 144
+	KeyF17 Key = 145
+	KeyF18 Key = 146
+	KeyF19 Key = 147
+	KeyF20 Key = 148
+	KeyF21 Key = 149
+	KeyF22 Key = 150
+	KeyF23 Key = 151
+	KeyF24 Key = 152
+
+	// the keys below are placed outside of the range of unicode so that
+	// they can never be confused with characters.
+
+	KeyMediaPlay        Key = 0x110000
+	KeyMediaPause       Key = 0x110001
+	KeyMediaStop        Key = 0x110002
+	KeyMediaNext        Key = 0x110003
+	KeyMediaPrevious    Key = 0x110004
+	KeyMediaFastForward Key = 0x110005
+	KeyMediaRewind      Key = 0x110006
+	KeyMediaRecord      Key = 0x110007
+	KeyEject            Key = 0x110008
+
+	KeyVolumeUp   Key = 0x110010
+	KeyVolumeDown Key = 0x110011
+	KeyMute       Key = 0x110012
+	KeyMicMute    Key = 0x110013
+
+	KeyBrightnessUp   Key = 0x110020
+	KeyBrightnessDown Key = 0x110021
+
+	KeyBrowserBack      Key = 0x110030
+	KeyBrowserForward   Key = 0x110031
+	KeyBrowserStop      Key = 0x110032
+	KeyBrowserRefresh   Key = 0x110033
+	KeyBrowserHome      Key = 0x110034
+	KeyBrowserSearch    Key = 0x110035
+	KeyBrowserFavorites Key = 0x110036
+
+	KeyMail       Key = 0x110040
+	KeyCalculator Key = 0x110041
 )
 
 // Button represents a mouse button.
@@ -107,7 +151,9 @@ type Modifiers struct {
 	// NumberPad does not represent a key, but it behaves like one. If it is
 	// set to true, the Key was pressed on the number pad. It is treated
 	// as a modifier key because if you don't care whether a key was pressed
-	// on the number pad or not, you can just ignore this value.
+	// on the number pad or not, you can just ignore this value. This is how
+	// keypad operators are told apart from their counterparts on the main
+	// keyboard: the keypad plus key is sent as '+' with NumberPad set.
 	NumberPad bool
 }
 
@@ -0,0 +1,210 @@
+package input
+
+import "fmt"
+import "errors"
+import "strings"
+import "strconv"
+import "unicode/utf8"
+
+var keyNames = map[Key] string {
+	KeyInsert:      "Insert",
+	KeyMenu:        "Menu",
+	KeyPrintScreen: "PrintScreen",
+	KeyPause:       "Pause",
+	KeyCapsLock:    "CapsLock",
+	KeyScrollLock:  "ScrollLock",
+	KeyNumLock:     "NumLock",
+	KeyBackspace:   "Backspace",
+	KeyTab:         "Tab",
+	KeyEnter:       "Enter",
+	KeyEscape:      "Escape",
+
+	KeyUp:       "Up",
+	KeyDown:     "Down",
+	KeyLeft:     "Left",
+	KeyRight:    "Right",
+	KeyPageUp:   "PageUp",
+	KeyPageDown: "PageDown",
+	KeyHome:     "Home",
+	KeyEnd:      "End",
+
+	KeyLeftShift:    "LeftShift",
+	KeyRightShift:   "RightShift",
+	KeyLeftControl:  "LeftControl",
+	KeyRightControl: "RightControl",
+	KeyLeftAlt:      "LeftAlt",
+	KeyRightAlt:     "RightAlt",
+	KeyLeftMeta:     "LeftMeta",
+	KeyRightMeta:    "RightMeta",
+	KeyLeftSuper:    "LeftSuper",
+	KeyRightSuper:   "RightSuper",
+	KeyLeftHyper:    "LeftHyper",
+	KeyRightHyper:   "RightHyper",
+
+	KeyDelete: "Delete",
+	KeyDead:   "Dead",
+
+	KeyF1:  "F1",
+	KeyF2:  "F2",
+	KeyF3:  "F3",
+	KeyF4:  "F4",
+	KeyF5:  "F5",
+	KeyF6:  "F6",
+	KeyF7:  "F7",
+	KeyF8:  "F8",
+	KeyF9:  "F9",
+	KeyF10: "F10",
+	KeyF11: "F11",
+	KeyF12: "F12",
+	KeyF13: "F13",
+	KeyF14: "F14",
+	KeyF15: "F15",
+	KeyF16: "F16",
+	KeyF17: "F17",
+	KeyF18: "F18",
+	KeyF19: "F19",
+	KeyF20: "F20",
+	KeyF21: "F21",
+	KeyF22: "F22",
+	KeyF23: "F23",
+	KeyF24: "F24",
+
+	KeyMediaPlay:        "MediaPlay",
+	KeyMediaPause:       "MediaPause",
+	KeyMediaStop:        "MediaStop",
+	KeyMediaNext:        "MediaNext",
+	KeyMediaPrevious:    "MediaPrevious",
+	KeyMediaFastForward: "MediaFastForward",
+	KeyMediaRewind:      "MediaRewind",
+	KeyMediaRecord:      "MediaRecord",
+	KeyEject:            "Eject",
+
+	KeyVolumeUp:   "VolumeUp",
+	KeyVolumeDown: "VolumeDown",
+	KeyMute:       "Mute",
+	KeyMicMute:    "MicMute",
+
+	KeyBrightnessUp:   "BrightnessUp",
+	KeyBrightnessDown: "BrightnessDown",
+
+	KeyBrowserBack:      "BrowserBack",
+	KeyBrowserForward:   "BrowserForward",
+	KeyBrowserStop:      "BrowserStop",
+	KeyBrowserRefresh:   "BrowserRefresh",
+	KeyBrowserHome:      "BrowserHome",
+	KeyBrowserSearch:    "BrowserSearch",
+	KeyBrowserFavorites: "BrowserFavorites",
+
+	KeyMail:       "Mail",
+	KeyCalculator: "Calculator",
+
+	Key(' '): "Space",
+	Key('+'): "Plus",
+}
+
+// keyAliases lists alternate names that are accepted when parsing, but never
+// produced when formatting.
+var keyAliases = map[string] Key {
+	"ins":    KeyInsert,
+	"prtsc":  KeyPrintScreen,
+	"return": KeyEnter,
+	"esc":    KeyEscape,
+	"pgup":   KeyPageUp,
+	"pgdn":   KeyPageDown,
+	"del":    KeyDelete,
+}
+
+// keysByName maps the lower case form of every key name and alias to its key,
+// so that names can be looked up without searching through keyNames.
+var keysByName = func () map[string] Key {
+	keys := make(map[string] Key, len(keyNames) + len(keyAliases))
+	for key, name := range keyNames {
+		keys[strings.ToLower(name)] = key
+	}
+	for name, key := range keyAliases {
+		keys[name] = key
+	}
+	return keys
+} ()
+
+// String returns a human-readable name for the key. Printable keys are
+// represented by their character, except for the space and plus keys which are
+// named "Space" and "Plus" so that they can be used in chords. Keys without a
+// name are formatted as "Key(n)". The result can be parsed by ParseKey.
+func (key Key) String () string {
+	if name, ok := keyNames[key]; ok { return name }
+	if key.Printable() { return string(rune(key)) }
+	return fmt.Sprint("Key(", int(key), ")")
+}
+
+// ParseKey parses a key name as returned by Key.String. Names are not case
+// sensitive, but single characters are taken literally.
+func ParseKey (name string) (key Key, err error) {
+	if utf8.RuneCountInString(name) == 1 {
+		char, _ := utf8.DecodeRuneInString(name)
+		return Key(char), nil
+	}
+	if key, ok := keysByName[strings.ToLower(name)]; ok {
+		return key, nil
+	}
+	if strings.HasPrefix(name, "Key(") && strings.HasSuffix(name, ")") {
+		number, err := strconv.Atoi(name[4:len(name) - 1])
+		if err == nil { return Key(number), nil }
+	}
+	return KeyNone, errors.New("unknown key \"" + name + "\"")
+}
+
+// String returns a human-readable list of the modifiers that are held down,
+// separated by plus signs. For example: "Ctrl+Shift". Modifiers are always
+// listed in the same order.
+func (modifiers Modifiers) String () string {
+	names := []string { }
+	if modifiers.Control   { names = append(names, "Ctrl")      }
+	if modifiers.Shift     { names = append(names, "Shift")     }
+	if modifiers.Alt       { names = append(names, "Alt")       }
+	if modifiers.Meta      { names = append(names, "Meta")      }
+	if modifiers.Super     { names = append(names, "Super")     }
+	if modifiers.Hyper     { names = append(names, "Hyper")     }
+	if modifiers.NumberPad { names = append(names, "NumberPad") }
+	return strings.Join(names, "+")
+}
+
+// FormatChord returns a human-readable representation of a key being pressed
+// while a set of modifiers are held down, such as "Ctrl+Alt+Delete". The result
+// can be parsed by ParseChord.
+func FormatChord (key Key, modifiers Modifiers) string {
+	prefix := modifiers.String()
+	if prefix == "" { return key.String() }
+	return prefix + "+" + key.String()
+}
+
+// ParseChord parses a string such as "Ctrl+Alt+Delete" into a key and a set of
+// modifiers. Modifier and key names are separated by plus signs, and the key
+// must come last. Names are not case sensitive. A trailing plus sign after a
+// separator, as in "Ctrl++", is treated as the plus key.
+func ParseChord (text string) (key Key, modifiers Modifiers, err error) {
+	if text == "" { return KeyNone, modifiers, errors.New("empty chord") }
+
+	parts := strings.Split(text, "+")
+	if text == "+" || strings.HasSuffix(text, "++") {
+		parts = append(parts[:len(parts) - 2], "+")
+	}
+
+	for _, part := range parts[:len(parts) - 1] {
+		switch strings.ToLower(strings.TrimSpace(part)) {
+		case "shift":           modifiers.Shift     = true
+		case "ctrl", "control": modifiers.Control   = true
+		case "alt":             modifiers.Alt       = true
+		case "meta":            modifiers.Meta      = true
+		case "super":           modifiers.Super     = true
+		case "hyper":           modifiers.Hyper     = true
+		case "numberpad":       modifiers.NumberPad = true
+		default:
+			return KeyNone, modifiers, errors.New (
+				"unknown modifier \"" + part + "\"")
+		}
+	}
+
+	key, err = ParseKey(strings.TrimSpace(parts[len(parts) - 1]))
+	return key, modifiers, err
+}
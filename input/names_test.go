@@ -0,0 +1,89 @@
+package input
+
+import "testing"
+
+func TestChordRoundTrip (test *testing.T) {
+	control := Modifiers { Control: true }
+	cases := []struct {
+		key       Key
+		modifiers Modifiers
+		text      string
+	} {
+		{ 'a',           Modifiers { },                           "a"                      },
+		{ 'S',           Modifiers { Control: true, Shift: true }, "Ctrl+Shift+S"           },
+		{ KeyDelete,     Modifiers { Control: true, Alt: true },   "Ctrl+Alt+Delete"        },
+		{ KeyF24,        Modifiers { Super: true },                "Super+F24"              },
+		{ KeyMediaPlay,  Modifiers { },                           "MediaPlay"              },
+		{ ' ',           control,                                 "Ctrl+Space"             },
+		{ '+',           control,                                 "Ctrl+Plus"              },
+		{ 'é',           Modifiers { Alt: true },                 "Alt+é"                  },
+		{ Key(0x9f),     Modifiers { Meta: true, Hyper: true },   "Meta+Hyper+Key(159)"    },
+		{ KeyEnter,      Modifiers { NumberPad: true },           "NumberPad+Enter"        },
+		{ KeyPageDown,   Modifiers {
+			Control: true, Shift: true, Alt: true, Meta: true,
+			Super: true, Hyper: true, NumberPad: true,
+		}, "Ctrl+Shift+Alt+Meta+Super+Hyper+NumberPad+PageDown" },
+	}
+
+	for _, current := range cases {
+		text := FormatChord(current.key, current.modifiers)
+		if text != current.text {
+			test.Errorf("FormatChord: got %q, expected %q", text, current.text)
+		}
+		key, modifiers, err := ParseChord(text)
+		if err != nil {
+			test.Errorf("ParseChord(%q): %v", text, err)
+			continue
+		}
+		if key != current.key || modifiers != current.modifiers {
+			test.Errorf (
+				"ParseChord(%q): got %v, %v, expected %v, %v",
+				text, key, modifiers, current.key, current.modifiers)
+		}
+	}
+}
+
+func TestKeyNameRoundTrip (test *testing.T) {
+	for key, name := range keyNames {
+		parsed, err := ParseKey(name)
+		if err != nil || parsed != key {
+			test.Errorf("ParseKey(%q): got %v, %v, expected %v", name, parsed, err, key)
+		}
+	}
+}
+
+func TestParseChordAlternateSpellings (test *testing.T) {
+	cases := []struct {
+		text      string
+		key       Key
+		modifiers Modifiers
+	} {
+		{ "ctrl+shift+s",     's',         Modifiers { Control: true, Shift: true } },
+		{ "Control + Esc",    KeyEscape,   Modifiers { Control: true }              },
+		{ "Ctrl++",           '+',         Modifiers { Control: true }              },
+		{ "+",                '+',         Modifiers { }                            },
+		{ "PGDN",             KeyPageDown, Modifiers { }                            },
+		{ "alt+RETURN",       KeyEnter,    Modifiers { Alt: true }                  },
+	}
+
+	for _, current := range cases {
+		key, modifiers, err := ParseChord(current.text)
+		if err != nil {
+			test.Errorf("ParseChord(%q): %v", current.text, err)
+			continue
+		}
+		if key != current.key || modifiers != current.modifiers {
+			test.Errorf (
+				"ParseChord(%q): got %v, %v, expected %v, %v",
+				current.text, key, modifiers, current.key, current.modifiers)
+		}
+	}
+}
+
+func TestParseChordErrors (test *testing.T) {
+	for _, text := range []string { "", "Ctrl+", "Bogus+A", "Ctrl+NotAKey" } {
+		if _, _, err := ParseChord(text); err == nil {
+			test.Errorf("ParseChord(%q): expected an error", text)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// Package menu provides a declarative model for describing menus, such as the
+// ones found in menu bars and context menus. Menus described with this package
+// can be displayed by elements such as elements.MenuBar.
+package menu
+
+import "tomo"
+import "tomo/input"
+
+// Kind determines how a menu item behaves when it is activated.
+type Kind int; const (
+	// KindAction items run their OnActivate callback when activated.
+	KindAction Kind = iota
+
+	// KindSeparator items draw a line between groups of other items, and
+	// cannot be activated.
+	KindSeparator
+
+	// KindCheck items toggle their Checked value when activated.
+	KindCheck
+
+	// KindRadio items become checked when activated, and uncheck the other
+	// radio items in their group. A group is an unbroken run of radio items.
+	KindRadio
+)
+
+// Item is a single entry in a menu.
+type Item struct {
+	// Kind determines how the item behaves when it is activated.
+	Kind Kind
+
+	// Text is the label text of the item. An underscore marks the
+	// character after it as the item's mnemonic.
+	Text string
+
+	// Icon is displayed alongside the text. If it is set to tomo.IconNone
+	// no icon is displayed. Note that the zero value of tomo.Icon is a
+	// valid icon, so constructors set this to tomo.IconNone.
+	Icon tomo.Icon
+
+	// Accelerator is displayed alongside the text. To make it actually
+	// work when the menu is closed, use RegisterAccelerators. If its key is
+	// input.KeyNone no accelerator is displayed.
+	Accelerator tomo.Accelerator
+
+	// Disabled items are greyed out and cannot be activated.
+	Disabled bool
+
+	// Checked is the state of check and radio items.
+	Checked bool
+
+	// Submenu, if not nil, is opened when the item is activated instead of
+	// calling OnActivate.
+	Submenu Menu
+
+	// OnActivate is called when the item is activated. For check and radio
+	// items, it is called after Checked is updated.
+	OnActivate func ()
+}
+
+// Menu is a list of menu items.
+type Menu []*Item
+
+// Action creates a new item that calls onActivate when it is activated.
+func Action (text string, onActivate func ()) *Item {
+	return &Item {
+		Kind:       KindAction,
+		Text:       text,
+		Icon:       tomo.IconNone,
+		OnActivate: onActivate,
+	}
+}
+
+// Separator creates a new separator item.
+func Separator () *Item {
+	return &Item { Kind: KindSeparator, Icon: tomo.IconNone }
+}
+
+// Check creates a new check item. Its Checked value is toggled when it is
+// activated, after which onActivate is called.
+func Check (text string, checked bool, onActivate func ()) *Item {
+	return &Item {
+		Kind:       KindCheck,
+		Text:       text,
+		Icon:       tomo.IconNone,
+		Checked:    checked,
+		OnActivate: onActivate,
+	}
+}
+
+// Radio creates a new radio item. Radio items placed next to each other form a
+// group, and only one item in a group is checked at a time.
+func Radio (text string, checked bool, onActivate func ()) *Item {
+	return &Item {
+		Kind:       KindRadio,
+		Text:       text,
+		Icon:       tomo.IconNone,
+		Checked:    checked,
+		OnActivate: onActivate,
+	}
+}
+
+// Sub creates a new item that opens a submenu containing the given items.
+func Sub (text string, items ...*Item) *Item {
+	return &Item {
+		Kind:    KindAction,
+		Text:    text,
+		Icon:    tomo.IconNone,
+		Submenu: items,
+	}
+}
+
+// Activatable returns whether the item can be activated.
+func (item *Item) Activatable () bool {
+	return item.Kind != KindSeparator && !item.Disabled
+}
+
+// Activate activates the item at the specified index. Check items are toggled,
+// and radio items are checked while the rest of their group is unchecked.
+// Then, the item's OnActivate callback is called. Items with a submenu are not
+// affected, as they must be opened by whatever is displaying the menu.
+func (menu Menu) Activate (index int) {
+	if index < 0 || index >= len(menu) { return }
+	item := menu[index]
+	if !item.Activatable() || item.Submenu != nil { return }
+
+	switch item.Kind {
+	case KindCheck:
+		item.Checked = !item.Checked
+	case KindRadio:
+		start, end := menu.group(index)
+		for _, sibling := range menu[start:end] {
+			sibling.Checked = false
+		}
+		item.Checked = true
+	}
+
+	if item.OnActivate != nil { item.OnActivate() }
+}
+
+// group returns the bounds of the radio group containing the item at the
+// specified index.
+func (menu Menu) group (index int) (start, end int) {
+	start, end = index, index + 1
+	for start > 0 && menu[start - 1].Kind == KindRadio { start -- }
+	for end < len(menu) && menu[end].Kind == KindRadio { end ++ }
+	return
+}
+
+// RegisterAccelerators adds every accelerator in the menu and its submenus to
+// an accelerator table, so that they work even while the menu is not open. If
+// an accelerator conflicts with one already in the table, or with another one
+// in the menu, an error is returned and the table is left unchanged.
+func RegisterAccelerators (table *tomo.AcceleratorTable, menu Menu) error {
+	added := []tomo.Accelerator { }
+	err := registerAccelerators(table, menu, &added)
+	if err != nil {
+		for _, accelerator := range added {
+			table.Remove(accelerator)
+		}
+	}
+	return err
+}
+
+func registerAccelerators (
+	table *tomo.AcceleratorTable,
+	menu  Menu,
+	added *[]tomo.Accelerator,
+) error {
+	for index, item := range menu {
+		if item.Submenu != nil {
+			err := registerAccelerators(table, item.Submenu, added)
+			if err != nil { return err }
+			continue
+		}
+		if item.Accelerator.Key == input.KeyNone { continue }
+
+		menu, index := menu, index
+		err := table.Add(item.Accelerator, func () {
+			menu.Activate(index)
+		})
+		if err != nil { return err }
+		*added = append(*added, item.Accelerator)
+	}
+	return nil
+}
+
+// UnregisterAccelerators removes every accelerator in the menu and its
+// submenus from an accelerator table. It undoes RegisterAccelerators.
+func UnregisterAccelerators (table *tomo.AcceleratorTable, menu Menu) {
+	for _, item := range menu {
+		if item.Submenu != nil {
+			UnregisterAccelerators(table, item.Submenu)
+			continue
+		}
+		if item.Accelerator.Key == input.KeyNone { continue }
+		table.Remove(item.Accelerator)
+	}
+}
@@ -0,0 +1,108 @@
+package menu
+
+import "errors"
+import "testing"
+import "tomo"
+import "tomo/input"
+
+func TestActivate (test *testing.T) {
+	activated := 0
+	count := func () { activated ++ }
+	disabled := Action("Disabled", count)
+	disabled.Disabled = true
+	menu := Menu {
+		Action("Action", count),
+		Check("Check", false, count),
+		disabled,
+		Sub("Sub", Action("Inner", count)),
+		Separator(),
+	}
+
+	menu.Activate(0)
+	if activated != 1 { test.Error("action was not activated") }
+
+	menu.Activate(1)
+	if !menu[1].Checked || activated != 2 {
+		test.Error("check item was not checked")
+	}
+	menu.Activate(1)
+	if menu[1].Checked { test.Error("check item was not unchecked") }
+
+	activated = 0
+	menu.Activate(2)
+	menu.Activate(3)
+	menu.Activate(4)
+	menu.Activate(-1)
+	menu.Activate(len(menu))
+	if activated != 0 {
+		test.Errorf("%d items were activated, expected none", activated)
+	}
+}
+
+func TestActivateRadio (test *testing.T) {
+	menu := Menu {
+		Radio("A", true,  nil),
+		Radio("B", false, nil),
+		Radio("C", false, nil),
+		Separator(),
+		Radio("D", true,  nil),
+		Radio("E", false, nil),
+	}
+	checked := func () (states []bool) {
+		for _, item := range menu {
+			states = append(states, item.Checked)
+		}
+		return
+	}
+	expect := func (states ...bool) {
+		got := checked()
+		for index := range states {
+			if got[index] != states[index] {
+				test.Errorf("got %v, expected %v", got, states)
+				return
+			}
+		}
+	}
+
+	menu.Activate(2)
+	expect(false, false, true, false, true, false)
+	menu.Activate(5)
+	expect(false, false, true, false, false, true)
+	menu.Activate(2)
+	expect(false, false, true, false, false, true)
+}
+
+func TestRegisterAccelerators (test *testing.T) {
+	control := input.Modifiers { Control: true }
+	activated := ""
+	item := func (text string, key input.Key) *Item {
+		item := Action(text, func () { activated = text })
+		item.Accelerator = tomo.A(key, control)
+		return item
+	}
+
+	table := tomo.AcceleratorTable { }
+	err := RegisterAccelerators (&table, Menu {
+		item("Open", 'o'),
+		Sub("Edit", item("Copy", 'c')),
+		Action("None", nil),
+	})
+	if err != nil { test.Fatal(err) }
+	if !table.Activate('c', control) || activated != "Copy" {
+		test.Error("accelerator in submenu was not registered")
+	}
+
+	err = RegisterAccelerators (&table, Menu {
+		item("Save", 's'),
+		Sub("Edit", item("Paste", 'v'), item("Open", 'o')),
+	})
+	if !errors.Is(err, tomo.ErrAcceleratorConflict) {
+		test.Errorf("got %v, expected a conflict", err)
+	}
+	if table.Has(tomo.A('s', control)) || table.Has(tomo.A('v', control)) {
+		test.Error("table was left partly registered")
+	}
+	if !table.Activate('o', control) || activated != "Open" {
+		test.Error("existing accelerator was removed")
+	}
+}
@@ -0,0 +1,29 @@
+package menu
+
+import "image"
+import "errors"
+import "tomo"
+
+// ErrNoPresenter is returned by Present when nothing has been registered to
+// display menus.
+var ErrNoPresenter = errors.New("no menu presenter registered")
+
+// Presenter displays a menu as a popup, with its top left corner at the
+// specified position relative to the window.
+type Presenter func (window tomo.Window, position image.Point, items Menu) error
+
+var presenter Presenter
+
+// SetPresenter sets the function used to display popup menus, such as context
+// menus opened by the backend. This lets backends display menus without
+// depending on the package that draws them. The elements package registers
+// elements.PopupMenu when it is loaded.
+func SetPresenter (callback Presenter) {
+	presenter = callback
+}
+
+// Present displays a menu using the registered presenter.
+func Present (window tomo.Window, position image.Point, items Menu) error {
+	if presenter == nil { return ErrNoPresenter }
+	return presenter(window, position, items)
+}
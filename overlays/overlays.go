@@ -0,0 +1,44 @@
+// Package overlays contains patterns that draw a mark on top of another
+// pattern. Themes can use them to show states that their textures don't have
+// room for.
+package overlays
+
+import "image"
+import "image/color"
+import "art"
+import "art/shapes"
+
+// Dash draws a horizontal bar across the middle of another pattern. It is used
+// to mark indeterminate checkboxes.
+type Dash struct {
+	art.Pattern
+	Color color.RGBA
+}
+
+func (pattern Dash) Draw (destination art.Canvas, bounds image.Rectangle) image.Rectangle {
+	drawn := pattern.Pattern.Draw(destination, bounds)
+	thickness := bounds.Dy() / 6
+	if thickness < 1 { thickness = 1 }
+	bar := image.Rect (
+		bounds.Min.X + bounds.Dx() / 4,
+		bounds.Min.Y + (bounds.Dy() - thickness) / 2,
+		bounds.Max.X - bounds.Dx() / 4,
+		bounds.Min.Y + (bounds.Dy() + thickness) / 2)
+	shapes.FillColorRectangle (
+		destination, pattern.Color,
+		bar.Intersect(destination.Bounds()))
+	return drawn
+}
+
+// Outline draws a one pixel border around another pattern. It is used to mark
+// invalid input fields.
+type Outline struct {
+	art.Pattern
+	Color color.RGBA
+}
+
+func (pattern Outline) Draw (destination art.Canvas, bounds image.Rectangle) image.Rectangle {
+	drawn := pattern.Pattern.Draw(destination, bounds)
+	shapes.StrokeColorRectangle(destination, pattern.Color, bounds, 1)
+	return drawn
+}
@@ -9,6 +9,7 @@ import "golang.org/x/image/font"
 import "golang.org/x/image/font/basicfont"
 import "tomo"
 import "tomo/data"
+import "tomo/overlays"
 import "art"
 import "art/artutil"
 import "art/patterns"
@@ -185,7 +186,22 @@ func (Theme) MimeIcon (data.Mime, tomo.IconSize, tomo.Case) art.Icon {
 	return nil
 }
 
-func (Theme) Pattern (id tomo.Pattern, state tomo.State, c tomo.Case) art.Pattern {
+func (theme Theme) Pattern (id tomo.Pattern, state tomo.State, c tomo.Case) art.Pattern {
+	if state.Invalid {
+		state.Invalid = false
+		return overlays.Outline {
+			Pattern: theme.Pattern(id, state, c),
+			Color:   theme.Color(tomo.ColorRed, state, c),
+		}
+	}
+	if id == tomo.PatternButton && state.Indeterminate {
+		state.Indeterminate = false
+		return overlays.Dash {
+			Pattern: theme.Pattern(id, state, c),
+			Color:   theme.Color(tomo.ColorForeground, state, c),
+		}
+	}
+
 	offset := 0; switch {
 	case state.Disabled:            offset = 1
 	case state.Pressed && state.On: offset = 4
@@ -203,7 +219,8 @@ func (Theme) Pattern (id tomo.Pattern, state tomo.State, c tomo.Case) art.Patter
 	case tomo.PatternPinboard:   return defaultTextures[3][offset]
 	case tomo.PatternButton:
 		switch {
-		case c.Match("tomo", "checkbox", ""):  
+		case c.Match("tomo", "checkbox", ""),
+			c.Match("tomo", "radioButton", ""):
 			return defaultTextures[9][offset]
 		case c.Match("tomo", "piano", "flatKey"):
 			return defaultTextures[11][offset]
@@ -259,10 +276,26 @@ func (Theme) Padding (id tomo.Pattern, c tomo.Case) art.Inset {
 	case tomo.PatternSunken:
 		if c.Match("tomo", "progressBar", "") {
 			return art.I(2, 1, 1, 2)
+		} else if c.Match("tomo", "progressBarVertical", "") {
+			return art.I(2, 1, 1, 2)
 		} else if c.Match("tomo", "list", "") {
 			return art.I(2)
 		} else if  c.Match("tomo", "flowList", "") {
 			return art.I(2)
+		} else if c.Match("tomo", "statusBar", "segment") {
+			return art.I(2, 4)
+		} else {
+			return art.I(8)
+		}
+	case tomo.PatternRaised:
+		if c.Match("tomo", "menu", "") {
+			return art.I(4)
+		} else if c.Match("tomo", "menuBar", "") {
+			return art.I(4)
+		} else if c.Match("tomo", "toolbar", "") {
+			return art.I(4)
+		} else if c.Match("tomo", "statusBar", "") {
+			return art.I(2)
 		} else {
 			return art.I(8)
 		}
@@ -272,6 +305,12 @@ func (Theme) Padding (id tomo.Pattern, c tomo.Case) art.Inset {
 		} else {
 			return art.I(8)
 		}
+	case tomo.PatternHandle:
+		if c.Match("tomo", "paned", "") {
+			return art.I(3)
+		} else {
+			return art.I(8)
+		}
 	case tomo.PatternTableCell:  return art.I(5)
 	case tomo.PatternTableHead:  return art.I(5)
 	case tomo.PatternGutter:     return art.I(0)
@@ -61,6 +61,48 @@ var buttonCodeTable = map[xproto.Keysym] input.Key {
 	0xFFC7: input.KeyF10,
 	0xFFC8: input.KeyF11,
 	0xFFC9: input.KeyF12,
+	0xFFCA: input.KeyF13,
+	0xFFCB: input.KeyF14,
+	0xFFCC: input.KeyF15,
+	0xFFCD: input.KeyF16,
+	0xFFCE: input.KeyF17,
+	0xFFCF: input.KeyF18,
+	0xFFD0: input.KeyF19,
+	0xFFD1: input.KeyF20,
+	0xFFD2: input.KeyF21,
+	0xFFD3: input.KeyF22,
+	0xFFD4: input.KeyF23,
+	0xFFD5: input.KeyF24,
+
+	// these are vendor specific keysyms, see XF86keysym.h
+	0x1008FF14: input.KeyMediaPlay,
+	0x1008FF31: input.KeyMediaPause,
+	0x1008FF15: input.KeyMediaStop,
+	0x1008FF17: input.KeyMediaNext,
+	0x1008FF16: input.KeyMediaPrevious,
+	0x1008FF97: input.KeyMediaFastForward,
+	0x1008FF3E: input.KeyMediaRewind,
+	0x1008FF1C: input.KeyMediaRecord,
+	0x1008FF2C: input.KeyEject,
+
+	0x1008FF13: input.KeyVolumeUp,
+	0x1008FF11: input.KeyVolumeDown,
+	0x1008FF12: input.KeyMute,
+	0x1008FFB2: input.KeyMicMute,
+
+	0x1008FF02: input.KeyBrightnessUp,
+	0x1008FF03: input.KeyBrightnessDown,
+
+	0x1008FF26: input.KeyBrowserBack,
+	0x1008FF27: input.KeyBrowserForward,
+	0x1008FF28: input.KeyBrowserStop,
+	0x1008FF29: input.KeyBrowserRefresh,
+	0x1008FF18: input.KeyBrowserHome,
+	0x1008FF1B: input.KeyBrowserSearch,
+	0x1008FF30: input.KeyBrowserFavorites,
+
+	0x1008FF19: input.KeyMail,
+	0x1008FF1D: input.KeyCalculator,
 
 	// TODO: send this whenever a compose key, dead key, etc is pressed,
 	// and then send the resulting character while witholding the key
@@ -91,8 +91,12 @@ func (entity *entity) propagateAlt (callback func (*entity) bool) bool {
 
 	return true
 }
+// childAt returns the deepest entity under the specified point. Children are
+// checked from last to first, so that the topmost one is found when they
+// overlap.
 func (entity *entity) childAt (point image.Point) *entity {
-	for _, child := range entity.children {
+	for index := len(entity.children) - 1; index >= 0; index -- {
+		child := entity.children[index]
 		if point.In(child.bounds) {
 			return child.childAt(point)
 		}
@@ -101,7 +105,8 @@ func (entity *entity) childAt (point image.Point) *entity {
 }
 
 func (entity *entity) scrollTargetChildAt (point image.Point) *entity {
-	for _, child := range entity.children {
+	for index := len(entity.children) - 1; index >= 0; index -- {
+		child := entity.children[index]
 		if point.In(child.bounds) {
 			result := child.scrollTargetChildAt(point)
 			if result != nil { return result }
@@ -115,6 +120,67 @@ func (entity *entity) scrollTargetChildAt (point image.Point) *entity {
 	return nil
 }
 
+// invalidateAbove invalidates every entity that is drawn on top of this one,
+// and overlaps it. This only happens when a layered container places its
+// children such that they overlap each other.
+func (entity *entity) invalidateAbove () {
+	if entity.window == nil { return }
+	bounds := entity.clippedBounds
+	for child := entity; child.parent != nil; child = child.parent {
+		if !child.parent.childrenOverlap() { continue }
+		siblings := child.parent.children
+		above := false
+		for _, sibling := range siblings {
+			if sibling == child { above = true; continue }
+			if !above { continue }
+			sibling.invalidateOverlapping(bounds)
+		}
+	}
+}
+
+// invalidateOverlapping invalidates this entity and each of its descendants
+// that overlap the specified bounds. Children are clipped to their parent, so
+// if an entity does not overlap, none of its descendants do either.
+func (entity *entity) invalidateOverlapping (bounds image.Rectangle) {
+	if !entity.clippedBounds.Overlaps(bounds) { return }
+	entity.window.system.drawingInvalid.Add(entity)
+	for _, child := range entity.children {
+		child.invalidateOverlapping(bounds)
+	}
+}
+
+// invalidateUncovered redraws whatever is underneath a child that is about to
+// be moved or removed, if the entity's children might overlap. Other containers
+// are expected to redraw the area themselves when they lay out their children.
+func (entity *entity) invalidateUncovered (child *entity) {
+	if entity.window == nil || entity.window.system.invalidateIgnore { return }
+	if !entity.childrenOverlap() { return }
+	entity.invalidateOverlapping(child.clippedBounds)
+}
+
+func (entity *entity) childrenOverlap () bool {
+	container, ok := entity.element.(ability.LayeredContainer)
+	return ok && container.ChildrenOverlap()
+}
+
+// treePath returns the index of each of the entity's ancestors within their
+// parent, starting from the root, followed by the index of the entity itself.
+// Sorting entities by their paths puts them in the order they are drawn in.
+func (entity *entity) treePath () []int {
+	depth := 0
+	for ancestor := entity; ancestor.parent != nil; ancestor = ancestor.parent {
+		depth ++
+	}
+	path := make([]int, depth)
+	for ancestor := entity; ancestor.parent != nil; ancestor = ancestor.parent {
+		depth --
+		for index, sibling := range ancestor.parent.children {
+			if sibling == ancestor { path[depth] = index; break }
+		}
+	}
+	return path
+}
+
 func (entity *entity) forMouseTargetContainers (callback func (ability.MouseTargetContainer, tomo.Element)) {
 	if entity.parent == nil { return }
 	if parent, ok := entity.parent.element.(ability.MouseTargetContainer); ok {
@@ -198,6 +264,7 @@ func (ent *entity) Insert (index int, child tomo.Element) {
 }
 
 func (entity *entity) Disown (index int) {
+	entity.invalidateUncovered(entity.children[index])
 	entity.children[index].unlink()
 	entity.children = append (
 		entity.children[:index],
@@ -224,6 +291,7 @@ func (entity *entity) CountChildren () int {
 
 func (entity *entity) PlaceChild (index int, bounds image.Rectangle) {
 	child := entity.children[index]
+	entity.invalidateUncovered(child)
 	child.bounds = bounds
 	child.clip(entity.clippedBounds)
 	child.Invalidate()
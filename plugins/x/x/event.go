@@ -2,6 +2,7 @@ package x
 
 import "image"
 import "tomo"
+import "tomo/menu"
 import "tomo/input"
 import "tomo/ability"
 
@@ -134,6 +135,17 @@ func (window *window) handleKeyPress (
 		}
 	} else if key == input.KeyEscape && window.shy {
 		window.Close()
+	} else if window.accelerators.Activate(key, modifiers) {
+		// the key press was consumed by an accelerator
+	} else if modifiers.Alt && window.system.activateMnemonic(key) {
+		// the key press was consumed by a mnemonic
+	} else if window.focused != nil && isContextMenuKey(key, modifiers) {
+		position := image.Pt (
+			window.focused.bounds.Min.X,
+			window.focused.bounds.Max.Y)
+		anchor, ok := window.focused.element.(ability.ContextMenuAnchor)
+		if ok { position = anchor.ContextMenuAnchor() }
+		window.openContextMenu(window.focused, position)
 	} else if window.focused != nil {
 		focused, ok := window.focused.element.(ability.KeyboardTarget)
 		if ok { focused.HandleKeyDown(key, modifiers) }
@@ -188,7 +200,9 @@ func (window *window) handleButtonPress (
 	modifiers    := window.modifiersFromState(buttonEvent.State)
 
 	if !insideWindow && window.shy && !scrolling {
-		window.Close()
+		window.dismiss (image.Pt (
+			int(buttonEvent.RootX),
+			int(buttonEvent.RootY)))
 	} else if scrolling {
 		underneath := window.system.scrollTargetChildAt(point)
 		if underneath != nil {
@@ -215,6 +229,29 @@ func (window *window) handleButtonPress (
 				modifiers, child)
 		}
 		underneath.forMouseTargetContainers(callback)
+
+		if input.Button(buttonEvent.Detail) == input.ButtonRight {
+			window.openContextMenu(underneath, point)
+		}
+	}
+}
+
+func isContextMenuKey (key input.Key, modifiers input.Modifiers) bool {
+	return key == input.KeyMenu || (key == input.KeyF10 && modifiers.Shift)
+}
+
+// openContextMenu opens the context menu of the given entity at the specified
+// position. If the entity has no context menu, the one belonging to its
+// closest ancestor that has one is opened instead.
+func (window *window) openContextMenu (entity *entity, position image.Point) {
+	for ; entity != nil; entity = entity.parent {
+		target, ok := entity.element.(ability.ContextMenuTarget)
+		if !ok { continue }
+		items := target.ContextMenu(position)
+		if len(items) > 0 {
+			menu.Present(window, position, items)
+		}
+		return
 	}
 }
 
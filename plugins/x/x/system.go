@@ -1,7 +1,11 @@
 package x
 
+import "sort"
 import "image"
+import "unicode"
 import "art"
+import "tomo"
+import "tomo/input"
 import "tomo/ability"
 
 type entitySet map[*entity] struct { }
@@ -106,6 +110,56 @@ func (system *system) focusPrevious () {
 	system.focus(behind)
 }
 
+func (system *system) activateMnemonic (key input.Key) (handled bool) {
+	if !key.Printable() { return false }
+	char := unicode.ToLower(rune(key))
+
+	matches := []*entity { }
+	system.propagateAlt (func (entity *entity) bool {
+		child, ok := entity.element.(ability.Mnemonic)
+		if ok && mnemonicEnabled(child) && unicode.ToLower(child.Mnemonic()) == char {
+			matches = append(matches, entity)
+		}
+		return true
+	})
+
+	switch len(matches) {
+	case 0:
+		return false
+	case 1:
+		matches[0].element.(ability.Mnemonic).HandleMnemonic()
+		return true
+	}
+
+	// more than one element has this mnemonic, so focus the one after
+	// the currently focused one
+	next := matches[0]
+	for index, entity := range matches {
+		if entity == system.focused && index + 1 < len(matches) {
+			next = matches[index + 1]
+		}
+	}
+	if _, ok := next.element.(ability.Focusable); ok {
+		system.focus(next)
+	} else {
+		next.element.(ability.Mnemonic).HandleMnemonic()
+	}
+	return true
+}
+
+// mnemonicEnabled returns whether an element's mnemonic should respond. If the
+// element describes another element, that element must be enabled instead.
+func mnemonicEnabled (element tomo.Element) bool {
+	if describer, ok := element.(ability.Describer); ok {
+		element = describer.Target()
+		if element == nil { return false }
+	}
+	if enableable, ok := element.(ability.Enableable); ok {
+		return enableable.Enabled()
+	}
+	return true
+}
+
 func (system *system) propagate (callback func (*entity) bool) {
 	if system.child == nil { return }
 	system.child.propagate(callback)
@@ -165,12 +219,31 @@ func (system *system) draw () {
 	system.invalidateIgnore = true
 	defer func () { system.invalidateIgnore = false } ()
 
+	// anything layered on top of an entity that is about to be redrawn must
+	// be redrawn as well, or it will be covered up
+	invalid := make([]*entity, 0, len(system.drawingInvalid))
+	for entity := range system.drawingInvalid {
+		invalid = append(invalid, entity)
+	}
+	for _, entity := range invalid {
+		entity.invalidateAbove()
+	}
+
+	// draw entities in tree order, so that overlapping children are drawn
+	// in the correct order
+	queue := make([]drawEntry, 0, len(system.drawingInvalid))
 	for entity := range system.drawingInvalid {
 		if entity.clippedBounds.Empty() { continue }
-		entity.element.Draw (art.Cut (
+		queue = append(queue, drawEntry { entity, entity.treePath() })
+	}
+	sort.Slice(queue, func (i, j int) bool {
+		return pathLess(queue[i].path, queue[j].path)
+	})
+	for _, entry := range queue {
+		entry.entity.element.Draw (art.Cut (
 			system.canvas,
-			entity.clippedBounds))
-		finalBounds = finalBounds.Union(entity.clippedBounds)
+			entry.entity.clippedBounds))
+		finalBounds = finalBounds.Union(entry.entity.clippedBounds)
 	}
 	system.drawingInvalid = make(entitySet)
 
@@ -180,3 +253,17 @@ func (system *system) draw () {
 		system.pushFunc(finalBounds)
 	}
 }
+
+type drawEntry struct {
+	entity *entity
+	path   []int
+}
+
+// pathLess reports whether the entity at path a is drawn before the entity at
+// path b. Ancestors are drawn before their descendants.
+func pathLess (a, b []int) bool {
+	for index := 0; index < len(a) && index < len(b); index ++ {
+		if a[index] != b[index] { return a[index] < b[index] }
+	}
+	return len(a) < len(b)
+}
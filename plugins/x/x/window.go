@@ -27,12 +27,15 @@ type window struct {
 	title, application string
 
 	modalParent *window
+	menuParent  *window
 	hasModal    bool
 	shy         bool
 
 	selectionRequest *selectionRequest
 	selectionClaim   *selectionClaim
 
+	accelerators tomo.AcceleratorTable
+
 	metrics struct {
 		bounds image.Rectangle
 	}
@@ -244,6 +247,7 @@ func (window *window) NewMenu (bounds image.Rectangle) (tomo.MenuWindow, error)
 	menu, err := window.backend.newWindow (
 		bounds.Add(window.metrics.bounds.Min), true)
 	menu.shy = true
+	menu.menuParent = window
 	icccm.WmTransientForSet (
 		window.backend.connection,
 		menu.xWindow.Id,
@@ -293,6 +297,10 @@ func (window *window) Hide () {
 	if window.shy { window.ungrabInput() }
 }
 
+func (window *window) Accelerators () *tomo.AcceleratorTable {
+	return &window.accelerators
+}
+
 func (window *window) Copy (data data.Data) {
 	selectionAtom, err := xprop.Atm(window.backend.connection, clipboardName)
 	if err != nil { return }
@@ -332,6 +340,41 @@ func (window *window) Close () {
 	window.Adopt(nil)
 	delete(window.backend.windows, window.xWindow.Id)
 	window.xWindow.Destroy()
+	if window.menuParent != nil && window.menuParent.shy {
+		// we are a submenu, so give input back to the menu that opened
+		// us
+		window.menuParent.grabInput()
+	}
+}
+
+func (window *window) BeginResize () {
+	connection := window.backend.connection
+	pointer, err := xproto.QueryPointer (
+		connection.Conn(),
+		connection.RootWin()).Reply()
+	if err != nil { return }
+
+	// the window manager can't take over the pointer while we still have
+	// it grabbed from the button press. since the release will go to the
+	// window manager, nothing is being dragged anymore either.
+	mousebind.UngrabPointer(connection)
+	window.system.drags = [10]*entity { }
+	ewmh.WmMoveresizeExtra (
+		connection, window.xWindow.Id, ewmh.SizeBottomRight,
+		int(pointer.RootX), int(pointer.RootY), 1, 1)
+}
+
+// dismiss closes a menu window in response to a click outside of it. If the
+// click was also outside of the menu that opened it, that menu is dismissed as
+// well, so that clicking away from a chain of submenus closes all of them.
+func (window *window) dismiss (point image.Point) {
+	parent := window.menuParent
+	window.Close()
+	if parent != nil && parent.shy && !point.In(parent.metrics.bounds) {
+		if _, open := window.backend.windows[parent.xWindow.Id]; open {
+			parent.dismiss(point)
+		}
+	}
 }
 
 func (window *window) OnClose (callback func ()) {
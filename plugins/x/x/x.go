@@ -1,5 +1,6 @@
 package x
 
+import "time"
 import "tomo"
 import defaultTheme  "tomo/default/theme"
 import defaultConfig "tomo/default/config"
@@ -99,6 +100,35 @@ func (backend *backend) Do (callback func ()) {
 	backend.doChannel <- callback
 }
 
+func (backend *backend) NewTicker (interval time.Duration, callback func ()) (stop func ()) {
+	backend.assert()
+	ticker  := time.NewTicker(interval)
+	done    := make(chan struct { })
+	stopped := false
+	go func () {
+		defer ticker.Stop()
+		for {
+			select {
+			case <- ticker.C:
+				backend.Do (func () {
+					// the ticker might have been stopped between
+					// this callback being queued and it actually
+					// running
+					if !stopped { callback() }
+				})
+			case <- done:
+				return
+			}
+		}
+	} ()
+	
+	return func () {
+		if stopped { return }
+		stopped = true
+		close(done)
+	}
+}
+
 func (backend *backend) SetTheme (theme tomo.Theme) {
 	backend.assert()
 	if theme == nil {
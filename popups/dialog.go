@@ -24,6 +24,10 @@ type Button struct {
 	// OnPress specifies a callback to run when the button is pressed. If
 	// this callback is nil, the button will appear disabled.
 	OnPress func ()
+
+	// Mnemonic specifies whether Name contains a mnemonic such as
+	// "_Save", as if it were given to elements.Button.SetTextWithMnemonic.
+	Mnemonic bool
 }
 
 // NewDialog creates a new modal dialog window and returns it. If parent is nil,
@@ -64,7 +68,8 @@ func NewDialog (
 	window.Adopt(box)
 	
 	if len(buttons) == 0 {
-		button := elements.NewButton("OK")
+		button := elements.NewButton("")
+		button.SetTextWithMnemonic("_OK")
 		button.SetIcon(tomo.IconYes)
 		button.OnClick(window.Close)
 		controlRow.Adopt(button)
@@ -72,7 +77,12 @@ func NewDialog (
 	} else {
 		var button *elements.Button
 		for _, buttonDescriptor := range buttons {
-			button = elements.NewButton(buttonDescriptor.Name)
+			button = elements.NewButton("")
+			if buttonDescriptor.Mnemonic {
+				button.SetTextWithMnemonic(buttonDescriptor.Name)
+			} else {
+				button.SetText(buttonDescriptor.Name)
+			}
 			button.SetEnabled(buttonDescriptor.OnPress != nil)
 			button.OnClick (func () {
 				buttonDescriptor.OnPress()
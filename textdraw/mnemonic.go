@@ -0,0 +1,66 @@
+package textdraw
+
+import "image"
+import "image/color"
+import "golang.org/x/image/math/fixed"
+import "art"
+import "art/shapes"
+
+// ParseMnemonic parses label text that may contain a mnemonic. The character
+// directly after the first underscore is the mnemonic, and will be underlined
+// when drawn. Two underscores in a row produce a literal underscore. The text
+// with underscores removed is returned, along with the mnemonic character and
+// its index within that text. If there is no mnemonic, the returned character
+// will be zero and the index will be -1.
+func ParseMnemonic (text string) (display []rune, mnemonic rune, index int) {
+	index = -1
+	runes := []rune(text)
+	display = make([]rune, 0, len(runes))
+	for cursor := 0; cursor < len(runes); cursor ++ {
+		char := runes[cursor]
+		if char == '_' && cursor + 1 < len(runes) {
+			cursor ++
+			char = runes[cursor]
+			if char != '_' && index < 0 {
+				mnemonic = char
+				index    = len(display)
+			}
+		}
+		display = append(display, char)
+	}
+	return
+}
+
+// DrawUnderline draws a line underneath the rune at the specified index, as if
+// the text were drawn at the given offset using Draw. This can be used to
+// indicate which character of a label is a mnemonic.
+func (drawer Drawer) DrawUnderline (
+	destination art.Canvas,
+	color       color.RGBA,
+	offset      image.Point,
+	index       int,
+) (
+	updatedRegion image.Rectangle,
+) {
+	if drawer.face == nil || index < 0 { return }
+
+	var found    bool
+	var char     rune
+	var position fixed.Point26_6
+	drawer.For (func (current int, currentChar rune, currentPosition fixed.Point26_6) bool {
+		if current == index {
+			found    = true
+			char     = currentChar
+			position = currentPosition
+		}
+		return current < index
+	})
+	if !found { return }
+
+	advance, ok := drawer.face.GlyphAdvance(char)
+	if !ok { return }
+
+	start := offset.Add(image.Pt(position.X.Round(), position.Y.Round() + 1))
+	end   := start.Add(image.Pt(advance.Round() - 1, 0))
+	return shapes.ColorLine(destination, color, 1, start, end)
+}
@@ -237,7 +237,10 @@ const (
 	IconExpand
 
 	IconPin
-	IconUnpin)
+	IconUnpin
+
+	IconReveal
+	IconConceal)
 
 const (
 	// Status icons
@@ -412,6 +415,12 @@ type State struct {
 	// selected.
 	On bool
 
+	// Indeterminate should be set to true if the element that is using this
+	// pattern is neither on nor off, such as a checkbox that controls a list
+	// of items where only some of them are selected. If this is set, On
+	// should be false.
+	Indeterminate bool
+
 	// Focused should be set to true if the element that is using this
 	// pattern is currently focused.
 	Focused bool
@@ -425,4 +434,10 @@ type State struct {
 	// pattern is locked and cannot be interacted with. Disabled variations
 	// of patterns are typically flattened and greyed-out.
 	Disabled bool
+
+	// Invalid should be set to true if the element that is using this
+	// pattern contains a value that failed validation, such as a text box
+	// with a malformed email address in it. Invalid variations of patterns
+	// are typically drawn in an error color.
+	Invalid bool
 }
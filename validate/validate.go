@@ -0,0 +1,105 @@
+// Package validate provides validators for text input elements such as
+// elements.TextBox. A validator can refuse an edit outright, transform it, or
+// accept it while marking the field as invalid.
+package validate
+
+import "errors"
+import "regexp"
+import "strings"
+import "net/mail"
+import "unicode/utf8"
+
+// ErrReject is returned by a Validator to refuse an edit. Validators may wrap
+// it to give a more specific reason.
+var ErrReject = errors.New("edit rejected")
+
+// Validator checks a value that is about to be given to a text field. It
+// returns the value that should actually be used, which allows it to transform
+// edits. If the returned error is or wraps ErrReject, the edit is discarded
+// and the field keeps its old value. Any other non-nil error is accepted, but
+// marks the field as invalid, and its message should be displayed to the user.
+type Validator func (value string) (string, error)
+
+// Chain combines several validators into one, which runs each of them in
+// order. Each validator receives the value returned by the one before it. The
+// first error encountered is returned.
+func Chain (validators ...Validator) Validator {
+	return func (value string) (string, error) {
+		for _, validator := range validators {
+			if validator == nil { continue }
+			var err error
+			value, err = validator(value)
+			if err != nil { return value, err }
+		}
+		return value, nil
+	}
+}
+
+// Transform returns a validator that passes the value through a function, such
+// as strings.ToUpper. It never reports an error.
+func Transform (transform func (string) string) Validator {
+	return func (value string) (string, error) {
+		return transform(value), nil
+	}
+}
+
+// MaxLength returns a validator that rejects edits that would make the value
+// longer than the specified number of characters.
+func MaxLength (length int) Validator {
+	return func (value string) (string, error) {
+		if utf8.RuneCountInString(value) > length {
+			return value, ErrReject
+		}
+		return value, nil
+	}
+}
+
+// Required returns a validator that marks the value as invalid if it is empty.
+func Required (message string) Validator {
+	return func (value string) (string, error) {
+		if value == "" { return value, errors.New(message) }
+		return value, nil
+	}
+}
+
+// Regexp returns a validator that marks the value as invalid if it does not
+// match the specified expression. An empty value is not checked, so that a
+// field does not start out invalid. Combine this with Required if necessary.
+func Regexp (expression *regexp.Regexp, message string) Validator {
+	return func (value string) (string, error) {
+		if value == "" || expression.MatchString(value) {
+			return value, nil
+		}
+		return value, errors.New(message)
+	}
+}
+
+// Integer returns a validator that rejects any edit that could not be part of
+// an integer. If signed is true, a leading minus sign is allowed.
+func Integer (signed bool) Validator {
+	return func (value string) (string, error) {
+		digits := value
+		if signed { digits = strings.TrimPrefix(value, "-") }
+		for _, char := range digits {
+			if char < '0' || char > '9' { return value, ErrReject }
+		}
+		if digits == "" && value != "" {
+			return value, errors.New("expected a number")
+		}
+		return value, nil
+	}
+}
+
+// Email returns a validator that marks the value as invalid if it is not a
+// single email address, such as "someone@example.com". An empty value is not
+// checked.
+func Email () Validator {
+	return func (value string) (string, error) {
+		if value == "" { return value, nil }
+		address, err := mail.ParseAddress(value)
+		if err != nil || address.Address != value {
+			return value, errors.New("expected an email address")
+		}
+		return value, nil
+	}
+}
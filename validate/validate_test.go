@@ -0,0 +1,127 @@
+package validate
+
+import "errors"
+import "regexp"
+import "strings"
+import "testing"
+
+// result describes what a validator is expected to do with a value.
+type result int; const (
+	accepted result = iota
+	invalid
+	rejected
+)
+
+func (result result) String () string {
+	switch result {
+	case accepted: return "accepted"
+	case invalid:  return "invalid"
+	default:       return "rejected"
+	}
+}
+
+func check (err error) result {
+	switch {
+	case err == nil:                return accepted
+	case errors.Is(err, ErrReject): return rejected
+	default:                        return invalid
+	}
+}
+
+func testValidator (
+	test      *testing.T,
+	name      string,
+	validator Validator,
+	cases     map[string] result,
+) {
+	test.Helper()
+	for value, expected := range cases {
+		_, err := validator(value)
+		if got := check(err); got != expected {
+			test.Errorf("%s(%q): %v, expected %v", name, value, got, expected)
+		}
+	}
+}
+
+func TestMaxLength (test *testing.T) {
+	testValidator(test, "MaxLength", MaxLength(3), map[string] result {
+		"":     accepted,
+		"abc":  accepted,
+		"äöü":  accepted,
+		"abcd": rejected,
+	})
+}
+
+func TestRequired (test *testing.T) {
+	testValidator(test, "Required", Required("required"), map[string] result {
+		"":  invalid,
+		" ": accepted,
+		"a": accepted,
+	})
+	_, err := Required("enter a name")("")
+	if err == nil || err.Error() != "enter a name" {
+		test.Errorf("got %v, expected the given message", err)
+	}
+}
+
+func TestInteger (test *testing.T) {
+	testValidator(test, "Integer(false)", Integer(false), map[string] result {
+		"":    accepted,
+		"042": accepted,
+		"-1":  rejected,
+		"1.5": rejected,
+		"1a":  rejected,
+	})
+	testValidator(test, "Integer(true)", Integer(true), map[string] result {
+		"-":   invalid,
+		"-12": accepted,
+		"1-":  rejected,
+		"--1": rejected,
+	})
+}
+
+func TestEmail (test *testing.T) {
+	testValidator(test, "Email", Email(), map[string] result {
+		"":                        accepted,
+		"someone@example.com":     accepted,
+		"someone":                 invalid,
+		"Someone <a@example.com>": invalid,
+		"a@example.com, b@c.com":  invalid,
+	})
+}
+
+func TestRegexp (test *testing.T) {
+	expression := regexp.MustCompile(`^[0-9a-f]+$`)
+	testValidator(test, "Regexp", Regexp(expression, "hex"), map[string] result {
+		"":     accepted,
+		"c0de": accepted,
+		"code": invalid,
+	})
+}
+
+func TestChain (test *testing.T) {
+	validator := Chain (
+		Transform(strings.ToUpper),
+		nil,
+		MaxLength(4),
+		Regexp(regexp.MustCompile(`^[A-Z]*$`), "letters only"))
+
+	value, err := validator("abc")
+	if value != "ABC" || err != nil {
+		test.Errorf("got %q, %v, expected \"ABC\", nil", value, err)
+	}
+	testValidator(test, "Chain", validator, map[string] result {
+		"abcde": rejected,
+		"ab1":   invalid,
+	})
+
+	// the first error stops the chain
+	validator = Chain(Required("required"), MaxLength(-1))
+	if _, err := validator(""); check(err) != invalid {
+		test.Errorf("got %v, expected the first validator's error", err)
+	}
+	value, err = Chain()("unchanged")
+	if value != "unchanged" || err != nil {
+		test.Errorf("empty chain: got %q, %v", value, err)
+	}
+}
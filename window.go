@@ -43,6 +43,12 @@ type Window interface {
 	// positioned relative to it.
 	NewMenu (bounds image.Rectangle) (MenuWindow, error)
 	
+	// Accelerators returns the window's accelerator table. Whenever a key is
+	// pressed within the window, this table is consulted before the key
+	// press is sent to the focused element. If an accelerator matches, the
+	// key press is consumed and never reaches the focused element.
+	Accelerators () *AcceleratorTable
+	
 	// Copy puts data into the clipboard.
 	Copy (data.Data)
 
@@ -62,6 +68,13 @@ type Window interface {
 	// Close closes the window.
 	Close ()
 
+	// BeginResize lets the user resize the window by moving the mouse, as
+	// if they had grabbed its bottom right corner. It should be called in
+	// response to a mouse button being pressed, and the resize ends when
+	// that button is released. Elements will not receive the release. This
+	// method might have no effect with some backends.
+	BeginResize ()
+
 	// OnClose specifies a function to be called when the window is closed.
 	OnClose (func ())
 }